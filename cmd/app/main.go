@@ -4,12 +4,24 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/indragunawan95/topedcrawler/files/config"
 	"github.com/indragunawan95/topedcrawler/internal/entity"
+	categoryRepo "github.com/indragunawan95/topedcrawler/internal/repo/category"
 	csvRepo "github.com/indragunawan95/topedcrawler/internal/repo/csv"
+	dictionaryRepo "github.com/indragunawan95/topedcrawler/internal/repo/dictionary"
+	discussionRepo "github.com/indragunawan95/topedcrawler/internal/repo/discussion"
+	jsonRepo "github.com/indragunawan95/topedcrawler/internal/repo/jsonl"
+	listingRepo "github.com/indragunawan95/topedcrawler/internal/repo/listing"
 	productRepo "github.com/indragunawan95/topedcrawler/internal/repo/product"
+	rankRepo "github.com/indragunawan95/topedcrawler/internal/repo/rank"
+	reviewRepo "github.com/indragunawan95/topedcrawler/internal/repo/review"
 	scrapperRepo "github.com/indragunawan95/topedcrawler/internal/repo/scrapper"
+	seedRepo "github.com/indragunawan95/topedcrawler/internal/repo/seed"
+	shopRepo "github.com/indragunawan95/topedcrawler/internal/repo/shop"
 	urlRepo "github.com/indragunawan95/topedcrawler/internal/repo/url"
 	scrapperUsecase "github.com/indragunawan95/topedcrawler/internal/usecase/scrappermanager"
 
@@ -25,6 +37,31 @@ func main() {
 		log.Fatalf("Error getting information varibale: %v", err)
 	}
 
+	selectors, err := scrapperRepo.LoadSelectors(cfg.App.SelectorsFile)
+	if err != nil {
+		log.Fatalf("Error loading selectors: %v", err)
+	}
+	siteSelectors, err := selectors.Site("tokopedia")
+	if err != nil {
+		log.Fatalf("Error loading selectors: %v", err)
+	}
+	for _, page := range []string{"pdp", "listing"} {
+		if _, err := selectors.Page("tokopedia", page); err != nil {
+			log.Fatalf("Error loading selectors: %v", err)
+		}
+	}
+	log.Printf("Loaded selectors version %s", selectors.Version)
+
+	seeds, err := seedRepo.LoadSeeds(cfg.App.SeedsFile)
+	if err != nil {
+		log.Fatalf("Error loading seeds: %v", err)
+	}
+
+	phones, err := dictionaryRepo.LoadPhoneDictionary(cfg.App.PhonesFile)
+	if err != nil {
+		log.Fatalf("Error loading phone dictionary: %v", err)
+	}
+
 	db, err := dbSetup(cfg)
 	if err != nil {
 		log.Fatal("Error:", err)
@@ -45,21 +82,49 @@ func main() {
 
 	productRepo := productRepo.New(db)
 	urlRepo := urlRepo.New(db)
-	scrapperRepo := scrapperRepo.New(browser)
+	listingRepo := listingRepo.New(db)
+	rankRepo := rankRepo.New(db)
+	shopRepo := shopRepo.New(db)
+	categoryRepo := categoryRepo.New(db)
+	reviewRepo := reviewRepo.New(db)
+	discussionRepo := discussionRepo.New(db)
+	newScrapper := func() scrapperUsecase.ScrapperRepoItf {
+		scrapper := scrapperRepo.New(browser)
+		scrapper.CaptureOperations(siteSelectors.Operations()...)
+		return scrapper
+	}
 	csvRepo := csvRepo.New("data.csv")
+	jsonRepo := jsonRepo.New("data.jsonl")
+
+	breaker := scrapperUsecase.BreakerConfig{
+		Threshold: cfg.App.BreakerThreshold,
+		Cooldown:  cfg.App.BreakerCooldown,
+	}
 
-	scrapperUc := scrapperUsecase.New(productRepo, urlRepo, scrapperRepo, csvRepo, numWorkers)
+	scrapperUc := scrapperUsecase.New(productRepo, urlRepo, listingRepo, rankRepo, shopRepo, categoryRepo, reviewRepo, discussionRepo, newScrapper, csvRepo, jsonRepo, siteSelectors, *seeds, *phones, numWorkers, cfg.App.ReviewPages, breaker)
 	// Get Seed Url
 	err = scrapperUc.GetAllProductLinks(context.Background(), numProducts)
 	if err != nil {
 		log.Fatalf("Error scraping product links: %v", err)
 	}
 
-	// Call the scrapper to process product details.
-	err = scrapperUc.ProductDetailsScrapper()
+	// Call the scrapper to process product details, an interrupt stops
+	// dispatching and skips the remaining urls.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	result, err := scrapperUc.ProductDetailsScrapper(ctx)
 	if err != nil {
 		log.Fatalf("Error scraping product details: %v", err)
 	}
+
+	log.Println(result.Summary())
+	if result.AbortErr != nil {
+		log.Fatalf("Run aborted: %v", result.AbortErr)
+	}
+	if result.Failed > 0 {
+		log.Printf("Failed urls:\n%v", result.Err())
+		os.Exit(1)
+	}
 }
 
 func dbSetup(cfg *config.Config) (*gorm.DB, error) {
@@ -87,11 +152,71 @@ func dbSetup(cfg *config.Config) (*gorm.DB, error) {
 	}
 
 	// Automigrate your models
-	err = db.AutoMigrate(&entity.ProductModel{}, &entity.UrlModel{})
+	err = db.AutoMigrate(&entity.ShopModel{}, &entity.CategoryModel{}, &entity.ProductModel{}, &entity.ProductVariantModel{}, &entity.ProductImageModel{}, &entity.ProductSpecModel{}, &entity.WholesaleTierModel{}, &entity.PhoneAttributesModel{}, &entity.ReviewModel{}, &entity.ListingSnapshotModel{}, &entity.RankEntryModel{}, &entity.DiscussionQuestionModel{}, &entity.DiscussionAnswerModel{}, &entity.UrlModel{})
 	if err != nil {
 		log.Fatal("failed to migrate:", err)
 		return nil, err
 	}
 
+	err = migrateProductPrice(db)
+	if err != nil {
+		log.Fatal("failed to migrate product price:", err)
+		return nil, err
+	}
+
+	err = migrateProductShop(db)
+	if err != nil {
+		log.Fatal("failed to migrate product shop:", err)
+		return nil, err
+	}
+
 	return db, nil
 }
+
+// migrateProductPrice moves the old digits-only varchar price column into
+// the typed price_amount (minor units) and price_currency columns
+func migrateProductPrice(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&entity.ProductModel{}, "price") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE products
+			SET price_amount = NULLIF(regexp_replace(price, '\D', '', 'g'), '')::bigint * 100,
+				price_currency = ?
+			WHERE price_amount IS NULL`, entity.CurrencyIDR).Error
+		if err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&entity.ProductModel{}, "price")
+	})
+}
+
+// migrateProductShop moves the old store_name column of products into the
+// shops table. Shops are keyed by the domain in the product url, rows from
+// before urls were stored fall back to the store name.
+func migrateProductShop(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&entity.ProductModel{}, "store_name") {
+		return nil
+	}
+
+	const domain = `COALESCE(NULLIF(split_part(regexp_replace(p.url, '^https?://[^/]+/', ''), '/', 1), ''), p.store_name)`
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT INTO shops (id, domain, name, badge, created_at, updated_at)
+			SELECT DISTINCT ON (`+domain+`) uuid_generate_v4(), `+domain+`, p.store_name, ?, now(), now()
+			FROM products p
+			WHERE p.shop_id IS NULL AND p.store_name <> ''
+			ON CONFLICT (domain) DO NOTHING`, entity.ShopBadgeNone).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`UPDATE products p SET shop_id = s.id
+			FROM shops s
+			WHERE p.shop_id IS NULL AND s.domain = ` + domain).Error
+		if err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&entity.ProductModel{}, "store_name")
+	})
+}
@@ -0,0 +1,228 @@
+// check-selectors runs the selector file against sample pages and prints a
+// per-field and per-element matrix of match, empty and error. It exits non-zero when a
+// required field fails on any page, so it can gate a crawl.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	scrapperRepo "github.com/indragunawan95/topedcrawler/internal/repo/scrapper"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// samplePage is either a live url or a stored html fixture
+type samplePage struct {
+	name    string
+	url     string
+	fixture string
+}
+
+func main() {
+	selectorsFile := flag.String("selectors", envOr("SELECTORS_FILE", "./files/config/selectors.yaml"), "selector file to check")
+	site := flag.String("site", "tokopedia", "site in the selector file")
+	page := flag.String("page", "pdp", "page type in the selector file")
+	urls := flag.String("urls", "", "comma separated live urls to check")
+	urlsFile := flag.String("urls-file", "", "file with one live url per line")
+	fixtures := flag.String("fixtures", "", "directory of stored .html pages to check")
+	sample := flag.Int("sample", 0, "check a random sample of this many pages, 0 checks all")
+	flag.Parse()
+
+	selectors, err := scrapperRepo.LoadSelectors(*selectorsFile)
+	if err != nil {
+		log.Fatalf("Error loading selectors: %v", err)
+	}
+	pageSelectors, err := selectors.Page(*site, *page)
+	if err != nil {
+		log.Fatalf("Error loading selectors: %v", err)
+	}
+
+	pages, err := samplePages(*urls, *urlsFile, *fixtures)
+	if err != nil {
+		log.Fatalf("Error reading sample pages: %v", err)
+	}
+	if *sample > 0 && *sample < len(pages) {
+		rand.Shuffle(len(pages), func(i, j int) { pages[i], pages[j] = pages[j], pages[i] })
+		pages = pages[:*sample]
+	}
+	if len(pages) == 0 {
+		log.Fatal("No sample pages, pass -urls, -urls-file or -fixtures")
+	}
+
+	pw, err := playwright.Run()
+	if err != nil {
+		log.Fatalf("could not start playwright: %v", err)
+	}
+	browser, err := pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
+		Headless: playwright.Bool(true),
+	})
+	if err != nil {
+		log.Fatalf("could not launch browser: %v", err)
+	}
+	scrapper := scrapperRepo.New(browser)
+	scrapper.CaptureOperations(pageSelectors.Operations()...)
+
+	// Elements are reported after the fields, results[i][j] is the cell of
+	// row j on page i
+	rows := append(append([]entity.FieldSelector{}, pageSelectors.Fields...), pageSelectors.Elements...)
+	results := make([][]string, len(pages))
+	failed := false
+	for i, p := range pages {
+		values, err := checkPage(scrapper, p, pageSelectors)
+		if err != nil {
+			log.Printf("Error loading %s: %v", p.name, err)
+			failed = true
+		}
+		results[i] = make([]string, len(rows))
+		for j, field := range rows {
+			cell := "error"
+			if err == nil {
+				cell = cellFor(values[j])
+			}
+			if !strings.HasPrefix(cell, "match") && field.Required {
+				failed = true
+			}
+			results[i][j] = cell
+		}
+	}
+
+	printMatrix(selectors.Version, rows, pages, results)
+
+	browser.Close()
+	pw.Stop()
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// checkPage extracts the fields of a page, then locates its elements. Pages
+// with a container, e.g. a listing, are checked on their first card.
+func checkPage(scrapper *scrapperRepo.ScrapperRepo, p samplePage, pageSelectors entity.PageSelectors) ([]entity.FieldValue, error) {
+	if err := scrapper.LaunchTab(); err != nil {
+		return nil, fmt.Errorf("failed to launch tab: %w", err)
+	}
+	defer scrapper.ClosePage()
+
+	if p.fixture != "" {
+		html, err := os.ReadFile(p.fixture)
+		if err != nil {
+			return nil, err
+		}
+		if err := scrapper.LoadHTML(string(html)); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := scrapper.OpenPage(p.url); err != nil {
+			return nil, err
+		}
+		if err := scrapper.ScrollPage(); err != nil {
+			return nil, err
+		}
+	}
+	if pageSelectors.Container != "" {
+		cards, err := scrapper.ExtractCards(pageSelectors)
+		if err != nil {
+			return nil, err
+		}
+		return append(cards[0], scrapper.LocateElements(pageSelectors.Elements)...), nil
+	}
+	values := scrapper.ExtractFields(pageSelectors.Fields)
+	return append(values, scrapper.LocateElements(pageSelectors.Elements)...), nil
+}
+
+// cellFor renders a field value as match, empty or error. Matches by
+// structured data or a fallback alternative name it, so dying primaries stand out.
+func cellFor(value entity.FieldValue) string {
+	switch {
+	case value.Err == nil && value.Source != scrapperRepo.SourceDOM:
+		return fmt.Sprintf("match(%s)", value.Source)
+	case value.Err == nil && value.MatchIndex > 0:
+		return fmt.Sprintf("match(%s)", value.Matched)
+	case value.Err == nil:
+		return "match"
+	case errors.Is(value.Err, scrapperRepo.ErrSelectorMissing):
+		return "empty"
+	}
+	return "error"
+}
+
+func printMatrix(version string, fields []entity.FieldSelector, pages []samplePage, results [][]string) {
+	fmt.Printf("selectors version %s\n\n", version)
+	for i, p := range pages {
+		fmt.Printf("[%d] %s\n", i, p.name)
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"FIELD", "REQUIRED"}
+	for i := range pages {
+		header = append(header, fmt.Sprintf("[%d]", i))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for j, field := range fields {
+		row := []string{field.Name, fmt.Sprint(field.Required)}
+		for i := range pages {
+			row = append(row, results[i][j])
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+func samplePages(urls, urlsFile, fixtures string) ([]samplePage, error) {
+	var pages []samplePage
+	for _, url := range strings.Split(urls, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			pages = append(pages, samplePage{name: url, url: url})
+		}
+	}
+
+	if urlsFile != "" {
+		file, err := os.Open(urlsFile)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			url := strings.TrimSpace(scanner.Text())
+			if url == "" || strings.HasPrefix(url, "#") {
+				continue
+			}
+			pages = append(pages, samplePage{name: url, url: url})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if fixtures != "" {
+		matches, err := filepath.Glob(filepath.Join(fixtures, "*.html"))
+		if err != nil {
+			return nil, err
+		}
+		for _, fixture := range matches {
+			pages = append(pages, samplePage{name: fixture, fixture: fixture})
+		}
+	}
+	return pages, nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
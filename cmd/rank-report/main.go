@@ -0,0 +1,112 @@
+// rank-report prints how products move in the seed listings between
+// discovery runs: the products that entered or left the top N in the last run,
+// and optionally the rank trajectory of every product, exported as csv.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indragunawan95/topedcrawler/files/config"
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	csvRepo "github.com/indragunawan95/topedcrawler/internal/repo/csv"
+	rankRepo "github.com/indragunawan95/topedcrawler/internal/repo/rank"
+	"github.com/indragunawan95/topedcrawler/internal/usecase/ranktracker"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func main() {
+	seed := flag.String("seed", "", "seed to report, empty reports every seed")
+	url := flag.String("url", "", "print the rank trajectory of this product url")
+	top := flag.Int("top", 10, "report products entering or leaving the top N")
+	csvFile := flag.String("csv", "", "export the rank trajectories of the reported seeds to this csv file")
+	flag.Parse()
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Error getting information varibale: %v", err)
+	}
+	datasource := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		cfg.DB.Host, cfg.DB.Port, cfg.DB.Username, cfg.DB.Password, cfg.DB.Name)
+	db, err := gorm.Open(postgres.Open(datasource), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("Error opening database connection: %v", err)
+	}
+
+	ctx := context.Background()
+	tracker := ranktracker.New(rankRepo.New(db))
+
+	seeds := []string{*seed}
+	if *seed == "" {
+		seeds, err = tracker.Seeds(ctx)
+		if err != nil {
+			log.Fatalf("Error getting seeds: %v", err)
+		}
+	}
+
+	var exported []entity.RankEntry
+	for _, s := range seeds {
+		changes, err := tracker.LatestTopNChanges(ctx, s, *top)
+		if err != nil {
+			log.Fatalf("Error comparing runs of %s: %v", s, err)
+		}
+		printChanges(changes)
+
+		if *url == "" && *csvFile == "" {
+			continue
+		}
+		trajectories, err := tracker.Trajectories(ctx, s, *url)
+		if err != nil {
+			log.Fatalf("Error getting trajectories of %s: %v", s, err)
+		}
+		for _, trajectory := range trajectories {
+			if *url != "" {
+				printTrajectory(trajectory)
+			}
+			exported = append(exported, trajectory.Entries...)
+		}
+	}
+
+	if *csvFile != "" {
+		if err := csvRepo.New(*csvFile).SaveRankHistoryToCSV(ctx, exported); err != nil {
+			log.Fatalf("Error exporting rank history: %v", err)
+		}
+		log.Printf("Exported %d rank entries to %s", len(exported), *csvFile)
+	}
+}
+
+func printChanges(changes ranktracker.TopNChanges) {
+	fmt.Printf("seed %s, top %d\n", changes.Seed, changes.N)
+	if changes.CurrentRun.IsZero() {
+		fmt.Println("  fewer than two runs, nothing to compare")
+		fmt.Println()
+		return
+	}
+	fmt.Printf("  %s -> %s\n", changes.PreviousRun.Format("2006-01-02 15:04"), changes.CurrentRun.Format("2006-01-02 15:04"))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  CHANGE\tPREVIOUS\tCURRENT\tURL")
+	for _, move := range changes.Entered {
+		fmt.Fprintf(w, "  entered\t-\t%d\t%s\n", move.CurrentRank, move.Url)
+	}
+	for _, move := range changes.Left {
+		fmt.Fprintf(w, "  left\t%d\t-\t%s\n", move.PreviousRank, move.Url)
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+func printTrajectory(trajectory ranktracker.Trajectory) {
+	ranks := make([]string, 0, len(trajectory.Entries))
+	for _, entry := range trajectory.Entries {
+		ranks = append(ranks, fmt.Sprintf("%s #%d", entry.RunAt.Format("2006-01-02"), entry.Rank))
+	}
+	fmt.Printf("%s %s\n  %s\n\n", trajectory.Seed, trajectory.Url, strings.Join(ranks, " -> "))
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -14,10 +15,18 @@ type Config struct {
 }
 
 type App struct {
-	Name        string `env-required:"true" yaml:"name" env:"APP_NAME"`
-	Version     string `env-required:"true" yaml:"version" env:"APP_VERSION"`
-	NumWorkers  int    `env-required:"true" yaml:"numworkers" env:"NUM_WORKERS"`
-	NumProducts int    `env-required:"true" yaml:"numproducts" env:"NUM_PRODUCTS"`
+	Name          string `env-required:"true" yaml:"name" env:"APP_NAME"`
+	SelectorsFile string `yaml:"selectorsfile" env:"SELECTORS_FILE" env-default:"./files/config/selectors.yaml"`
+	SeedsFile     string `yaml:"seedsfile" env:"SEEDS_FILE" env-default:"./files/config/seeds.yaml"`
+	PhonesFile    string `yaml:"phonesfile" env:"PHONES_FILE" env-default:"./files/config/phones.yaml"`
+	Version       string `env-required:"true" yaml:"version" env:"APP_VERSION"`
+	NumWorkers    int    `env-required:"true" yaml:"numworkers" env:"NUM_WORKERS"`
+	NumProducts   int    `env-required:"true" yaml:"numproducts" env:"NUM_PRODUCTS"`
+	// Max review pages scraped per product, 0 skips reviews
+	ReviewPages int `yaml:"reviewpages" env:"REVIEW_PAGES" env-default:"5"`
+	// Circuit breaker: trip after this many consecutive failures of the same class
+	BreakerThreshold int           `yaml:"breakerthreshold" env:"BREAKER_THRESHOLD" env-default:"10"`
+	BreakerCooldown  time.Duration `yaml:"breakercooldown" env:"BREAKER_COOLDOWN" env-default:"1m"`
 }
 
 type HTTP struct {
@@ -0,0 +1,11 @@
+package entity
+
+// Availability is the normalised stock state of a product snapshot
+type Availability string
+
+const (
+	AvailabilityUnknown    Availability = ""
+	AvailabilityInStock    Availability = "in_stock"
+	AvailabilityLowStock   Availability = "low_stock"
+	AvailabilityOutOfStock Availability = "out_of_stock"
+)
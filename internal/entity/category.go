@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CategoryPathSeparator joins category names into a path, e.g.
+// "Handphone & Tablet > Handphone > Android OS"
+const CategoryPathSeparator = " > "
+
+// Category is one level of the Tokopedia category tree. Categories are
+// identified by their full path so the same name under two parents stays apart.
+type Category struct {
+	ID         string
+	ParentID   string // empty for a top level category
+	ExternalID string // Tokopedia category ID, empty when unknown
+	Name       string
+	Url        string
+	Level      int // 1 for a top level category
+	Path       string
+}
+
+// CategoryPath joins the names of categories ordered from root to leaf
+func CategoryPath(categories []Category) string {
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+	return strings.Join(names, CategoryPathSeparator)
+}
+
+func (c Category) ToModel() CategoryModel {
+	model := CategoryModel{
+		ExternalID: c.ExternalID,
+		Name:       c.Name,
+		Url:        c.Url,
+		Level:      c.Level,
+		Path:       c.Path,
+	}
+	if c.ID != "" {
+		model.ID = uuid.MustParse(c.ID)
+	}
+	if c.ParentID != "" {
+		parentID := uuid.MustParse(c.ParentID)
+		model.ParentID = &parentID
+	}
+	return model
+}
+
+type CategoryModel struct {
+	gorm.Model            // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ParentID   *uuid.UUID `gorm:"type:uuid;index"`
+	ExternalID string     `gorm:"type:varchar(50);index"`
+	Name       string     `gorm:"type:varchar(100);not null"`
+	Url        string     `gorm:"type:text"`
+	Level      int        `gorm:"type:smallint;not null"`
+	Path       string     `gorm:"type:text;not null;uniqueIndex"`
+}
+
+func (CategoryModel) TableName() string {
+	return "categories"
+}
+
+func (c CategoryModel) ToEntity() Category {
+	var parentID string
+	if c.ParentID != nil {
+		parentID = c.ParentID.String()
+	}
+
+	return Category{
+		ID:         c.ID.String(),
+		ParentID:   parentID,
+		ExternalID: c.ExternalID,
+		Name:       c.Name,
+		Url:        c.Url,
+		Level:      c.Level,
+		Path:       c.Path,
+	}
+}
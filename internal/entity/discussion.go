@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DiscussionQuestion is a buyer question from the discussion (Q&A) tab of a
+// product. Questions are stored once per Tokopedia question ID.
+type DiscussionQuestion struct {
+	ID          string
+	ProductID   string
+	ProductUrl  string
+	ExternalID  string // Tokopedia question ID
+	Text        string
+	AskedBy     string
+	AskedAt     *time.Time
+	AskedAtText string // as shown on the page, e.g. "3 hari lalu"
+	AnswerCount int64
+	Answers     []DiscussionAnswer
+}
+
+// DiscussionAnswer is an answer to a discussion question, by the seller or another buyer
+type DiscussionAnswer struct {
+	ID             string
+	QuestionID     string
+	ExternalID     string // Tokopedia answer ID
+	Text           string
+	AnsweredBy     string
+	IsSeller       bool
+	AnsweredAt     *time.Time
+	AnsweredAtText string // as shown on the page, e.g. "2 hari lalu"
+}
+
+func (q DiscussionQuestion) ToModel() DiscussionQuestionModel {
+	answers := make([]DiscussionAnswerModel, 0, len(q.Answers))
+	for _, answer := range q.Answers {
+		answers = append(answers, answer.ToModel())
+	}
+
+	model := DiscussionQuestionModel{
+		ProductUrl:  q.ProductUrl,
+		ExternalID:  q.ExternalID,
+		Text:        q.Text,
+		AskedBy:     q.AskedBy,
+		AskedAt:     q.AskedAt,
+		AskedAtText: q.AskedAtText,
+		AnswerCount: q.AnswerCount,
+		Answers:     answers,
+	}
+	if q.ID != "" {
+		model.ID = uuid.MustParse(q.ID)
+	}
+	if q.ProductID != "" {
+		model.ProductID = uuid.MustParse(q.ProductID)
+	}
+	return model
+}
+
+func (a DiscussionAnswer) ToModel() DiscussionAnswerModel {
+	model := DiscussionAnswerModel{
+		ExternalID:     a.ExternalID,
+		Text:           a.Text,
+		AnsweredBy:     a.AnsweredBy,
+		IsSeller:       a.IsSeller,
+		AnsweredAt:     a.AnsweredAt,
+		AnsweredAtText: a.AnsweredAtText,
+	}
+	if a.ID != "" {
+		model.ID = uuid.MustParse(a.ID)
+	}
+	if a.QuestionID != "" {
+		model.QuestionID = uuid.MustParse(a.QuestionID)
+	}
+	return model
+}
+
+type DiscussionQuestionModel struct {
+	gorm.Model            // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	ProductUrl  string    `gorm:"type:text;not null;index"`
+	ExternalID  string    `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Text        string    `gorm:"type:text;not null"`
+	AskedBy     string    `gorm:"type:varchar(100)"`
+	AskedAt     *time.Time
+	AskedAtText string                  `gorm:"type:varchar(50)"`
+	AnswerCount int64                   `gorm:"type:bigint"`
+	Answers     []DiscussionAnswerModel `gorm:"foreignKey:QuestionID;references:ID"`
+}
+
+func (DiscussionQuestionModel) TableName() string {
+	return "discussion_questions"
+}
+
+type DiscussionAnswerModel struct {
+	gorm.Model               // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID             uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	QuestionID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	ExternalID     string    `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Text           string    `gorm:"type:text;not null"`
+	AnsweredBy     string    `gorm:"type:varchar(100)"`
+	IsSeller       bool      `gorm:"type:boolean;not null;default:false"`
+	AnsweredAt     *time.Time
+	AnsweredAtText string `gorm:"type:varchar(50)"`
+}
+
+func (DiscussionAnswerModel) TableName() string {
+	return "discussion_answers"
+}
+
+func (q DiscussionQuestionModel) ToEntity() DiscussionQuestion {
+	answers := make([]DiscussionAnswer, 0, len(q.Answers))
+	for _, answer := range q.Answers {
+		answers = append(answers, answer.ToEntity())
+	}
+
+	return DiscussionQuestion{
+		ID:          q.ID.String(),
+		ProductID:   q.ProductID.String(),
+		ProductUrl:  q.ProductUrl,
+		ExternalID:  q.ExternalID,
+		Text:        q.Text,
+		AskedBy:     q.AskedBy,
+		AskedAt:     q.AskedAt,
+		AskedAtText: q.AskedAtText,
+		AnswerCount: q.AnswerCount,
+		Answers:     answers,
+	}
+}
+
+func (a DiscussionAnswerModel) ToEntity() DiscussionAnswer {
+	return DiscussionAnswer{
+		ID:             a.ID.String(),
+		QuestionID:     a.QuestionID.String(),
+		ExternalID:     a.ExternalID,
+		Text:           a.Text,
+		AnsweredBy:     a.AnsweredBy,
+		IsSeller:       a.IsSeller,
+		AnsweredAt:     a.AnsweredAt,
+		AnsweredAtText: a.AnsweredAtText,
+	}
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "database/sql/driver"
+
+type FieldStatus string
+
+const (
+	FieldStatusOK      FieldStatus = "ok"
+	FieldStatusMissing FieldStatus = "missing"
+	FieldStatusError   FieldStatus = "error"
+)
+
+// FieldExtraction records how a single product field was extracted
+type FieldExtraction struct {
+	Status  FieldStatus `json:"status"`
+	Source  string      `json:"source,omitempty"`  // xhr, jsonld, state or dom
+	Matched string      `json:"matched,omitempty"` // selector alternative that matched
+	Error   string      `json:"error,omitempty"`
+}
+
+// FieldExtractions maps a field name to its extraction status.
+// It is stored as a jsonb column.
+type FieldExtractions map[string]FieldExtraction
+
+func (f FieldExtractions) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+	return jsonValue(f)
+}
+
+func (f *FieldExtractions) Scan(value interface{}) error {
+	*f = nil
+	return scanJSON(value, f)
+}
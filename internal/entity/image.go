@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductImage is one image of the product gallery at the highest resolution found
+type ProductImage struct {
+	ID        string
+	ProductID string
+	Position  int // zero based order in the gallery
+	Url       string
+	Width     int // zero when unknown
+	Height    int
+}
+
+func (i ProductImage) ToModel() ProductImageModel {
+	model := ProductImageModel{
+		Position: i.Position,
+		Url:      i.Url,
+		Width:    i.Width,
+		Height:   i.Height,
+	}
+	if i.ID != "" {
+		model.ID = uuid.MustParse(i.ID)
+	}
+	if i.ProductID != "" {
+		model.ProductID = uuid.MustParse(i.ProductID)
+	}
+	return model
+}
+
+type ProductImageModel struct {
+	gorm.Model           // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ProductID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Position   int       `gorm:"not null"`
+	Url        string    `gorm:"type:text;not null"`
+	Width      int
+	Height     int
+}
+
+func (ProductImageModel) TableName() string {
+	return "product_images"
+}
+
+func (i ProductImageModel) ToEntity() ProductImage {
+	return ProductImage{
+		ID:        i.ID.String(),
+		ProductID: i.ProductID.String(),
+		Position:  i.Position,
+		Url:       i.Url,
+		Width:     i.Width,
+		Height:    i.Height,
+	}
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListingSnapshot is one product card of a listing page as seen during
+// discovery. It is cheap to collect, so it also covers products whose detail
+// page is never opened.
+type ListingSnapshot struct {
+	ID            string
+	Seed          string // name of the seed the listing belongs to
+	PageIndex     int    // listing page, from 1
+	Position      int    // card position on the page, from 1
+	Rank          int    // card position across the pages of the seed, from 1
+	Url           string // product url, for sponsored cards the product behind the ad, empty when it cannot be resolved
+	AdUrl         string // ad redirect link of a sponsored card, empty for organic cards
+	Name          string
+	Price         Money
+	ShopCity      string
+	Rating        float32
+	SoldCountText string
+	SoldCount     int64
+	IsAd          bool // sponsored slot, from the ad link or the ad badge
+}
+
+func (l ListingSnapshot) ToModel() ListingSnapshotModel {
+	model := ListingSnapshotModel{
+		Seed:          l.Seed,
+		PageIndex:     l.PageIndex,
+		Position:      l.Position,
+		Rank:          l.Rank,
+		Url:           l.Url,
+		AdUrl:         l.AdUrl,
+		Name:          l.Name,
+		Price:         l.Price,
+		ShopCity:      l.ShopCity,
+		Rating:        l.Rating,
+		SoldCountText: l.SoldCountText,
+		SoldCount:     l.SoldCount,
+		IsAd:          l.IsAd,
+	}
+	if l.ID != "" {
+		model.ID = uuid.MustParse(l.ID)
+	}
+	return model
+}
+
+type ListingSnapshotModel struct {
+	gorm.Model              // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	Seed          string    `gorm:"type:varchar(100);not null;index"`
+	PageIndex     int       `gorm:"type:int;not null"`
+	Position      int       `gorm:"type:int;not null"`
+	Rank          int       `gorm:"type:int;not null"`
+	Url           string    `gorm:"type:text;not null;index"`
+	AdUrl         string    `gorm:"type:text"`
+	Name          string    `gorm:"type:text"`
+	Price         Money     `gorm:"embedded;embeddedPrefix:price_"`
+	ShopCity      string    `gorm:"type:varchar(100)"`
+	Rating        float32   `gorm:"type:decimal(10,2)"`
+	SoldCountText string    `gorm:"type:varchar(50)"`
+	SoldCount     int64     `gorm:"type:bigint"`
+	IsAd          bool      `gorm:"type:boolean;not null;default:false"`
+}
+
+func (ListingSnapshotModel) TableName() string {
+	return "listing_snapshots"
+}
+
+func (l ListingSnapshotModel) ToEntity() ListingSnapshot {
+	return ListingSnapshot{
+		ID:            l.ID.String(),
+		Seed:          l.Seed,
+		PageIndex:     l.PageIndex,
+		Position:      l.Position,
+		Rank:          l.Rank,
+		Url:           l.Url,
+		AdUrl:         l.AdUrl,
+		Name:          l.Name,
+		Price:         l.Price,
+		ShopCity:      l.ShopCity,
+		Rating:        l.Rating,
+		SoldCountText: l.SoldCountText,
+		SoldCount:     l.SoldCount,
+		IsAd:          l.IsAd,
+	}
+}
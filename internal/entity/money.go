@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const CurrencyIDR = "IDR"
+
+// machineNumber matches numbers coming from structured data, e.g. 1299000 or 1299000.5
+var machineNumber = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// Money is an amount in minor units of its currency, e.g. sen for IDR,
+// so Rp1.299.000 is stored as 129900000
+type Money struct {
+	Amount   int64  `gorm:"type:bigint"`
+	Currency string `gorm:"type:varchar(3)"`
+}
+
+// IsZero reports whether no amount was set
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Major formats the amount in major units with two decimals, e.g. "1299000.00"
+func (m Money) Major() string {
+	if m.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d.%02d", m.Amount/100, m.Amount%100)
+}
+
+// ParseIDR parses a rupiah price such as "Rp1.299.000", "Rp 1.299.000,50"
+// or, when structured, a plain number such as 1299000.5 into minor units.
+// Text from the page is always read with Indonesian separators, so
+// "150.000" is 150000 rupiah.
+func ParseIDR(text string, structured bool) (Money, error) {
+	text = strings.TrimSpace(text)
+	if structured && machineNumber.MatchString(text) {
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return Money{}, err
+		}
+		return Money{Amount: int64(value*100 + 0.5), Currency: CurrencyIDR}, nil
+	}
+
+	// Indonesian formatting uses dots for thousands and a comma for decimals
+	integer, fraction, _ := strings.Cut(text, ",")
+	integer = digitsOnly(integer)
+	fraction = digitsOnly(fraction)
+	if integer == "" {
+		return Money{}, fmt.Errorf("no amount in price %q", text)
+	}
+
+	amount, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid price %q: %w", text, err)
+	}
+	amount *= 100
+	if fraction != "" {
+		fraction = (fraction + "0")[:2]
+		sen, _ := strconv.ParseInt(fraction, 10, 64)
+		amount += sen
+	}
+	return Money{Amount: amount, Currency: CurrencyIDR}, nil
+}
+
+func digitsOnly(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, text)
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "testing"
+
+func TestParseIDR(t *testing.T) {
+	tests := []struct {
+		text       string
+		structured bool
+		want       int64 // minor units
+		wantErr    bool
+	}{
+		{"Rp1.299.000", false, 129900000, false},
+		{"Rp 1.299.000,50", false, 129900050, false},
+		{"Rp150.000", false, 15000000, false},
+		{"150.000", false, 15000000, false},
+		{"1299000", false, 129900000, false},
+		{"1299000", true, 129900000, false},
+		{"1299000.5", true, 129900050, false},
+		{"150.000", true, 15000, false}, // machine form, a decimal point
+		{"Rp", false, 0, true},
+		{"", true, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseIDR(tt.text, tt.structured)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIDR(%q, %v) error = %v, wantErr %v", tt.text, tt.structured, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got.Amount != tt.want || got.Currency != CurrencyIDR {
+			t.Errorf("ParseIDR(%q, %v) = %+v, want %d IDR", tt.text, tt.structured, got, tt.want)
+		}
+	}
+}
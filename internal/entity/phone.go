@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PhoneDictionary drives the phone attribute parsing, see files/config/phones.yaml
+type PhoneDictionary struct {
+	// Categories and Seeds tell which products are phones, a product is parsed
+	// when a breadcrumb level or its seed matches, case insensitive
+	Categories []string     `yaml:"categories"`
+	Seeds      []string     `yaml:"seeds"`
+	Brands     []PhoneBrand `yaml:"brands"`
+	// AccessoryWords mark a title as an accessory, e.g. "case" or "tempered
+	// glass", unless a BonusWords word comes first, e.g. "free case"
+	AccessoryWords []string `yaml:"accessory_words"`
+	BonusWords     []string `yaml:"bonus_words"`
+	// ModelStopWords end the model name in a title, e.g. "garansi" or "bnib"
+	ModelStopWords []string        `yaml:"model_stop_words"`
+	Memory         []MemoryPattern `yaml:"memory"`
+	Networks       []ValuePattern  `yaml:"networks"`
+	Warranties     []ValuePattern  `yaml:"warranties"`
+	SpecKeys       PhoneSpecKeys   `yaml:"spec_keys"`
+}
+
+// PhoneBrand is a brand and the words naming it in a title
+type PhoneBrand struct {
+	Name    string   `yaml:"name"`
+	Aliases []string `yaml:"aliases"` // words naming the brand, e.g. "samsung"
+	// Series are model lines implying the brand, e.g. "iphone" or "redmi".
+	// They start the model name.
+	Series []string `yaml:"series"`
+}
+
+// MemoryPattern reads RAM and/or storage from a title, e.g. "8/256GB".
+// Ram, Storage and Unit are capture group indexes, 0 when absent.
+type MemoryPattern struct {
+	Pattern    string  `yaml:"pattern"`
+	Ram        int     `yaml:"ram"`
+	Storage    int     `yaml:"storage"`
+	Unit       int     `yaml:"unit"` // storage unit, GB or TB, GB when absent
+	Confidence float64 `yaml:"confidence"`
+}
+
+// ValuePattern maps a title or spec value matching Pattern to Value
+type ValuePattern struct {
+	Value      string  `yaml:"value"`
+	Pattern    string  `yaml:"pattern"`
+	Confidence float64 `yaml:"confidence"`
+}
+
+// PhoneSpecKeys lists the normalised spec keys holding each attribute
+type PhoneSpecKeys struct {
+	Brand    []string `yaml:"brand"`
+	Ram      []string `yaml:"ram"`
+	Storage  []string `yaml:"storage"`
+	Network  []string `yaml:"network"`
+	Warranty []string `yaml:"warranty"`
+}
+
+// PhoneAttributes are the phone attributes of a product snapshot, parsed from
+// its title and specs. Each confidence is between 0 and 1, 0 when the
+// attribute was not found.
+type PhoneAttributes struct {
+	ID                 string
+	ProductID          string
+	Brand              string
+	BrandConfidence    float64
+	ModelName          string
+	ModelConfidence    float64
+	RamGB              *float64
+	RamConfidence      float64
+	StorageGB          *float64
+	StorageConfidence  float64
+	Network            string // e.g. 5G
+	NetworkConfidence  float64
+	Warranty           string // e.g. resmi or distributor
+	WarrantyConfidence float64
+}
+
+func (p PhoneAttributes) ToModel() PhoneAttributesModel {
+	model := PhoneAttributesModel{
+		Brand:              p.Brand,
+		BrandConfidence:    p.BrandConfidence,
+		ModelName:          p.ModelName,
+		ModelConfidence:    p.ModelConfidence,
+		RamGB:              p.RamGB,
+		RamConfidence:      p.RamConfidence,
+		StorageGB:          p.StorageGB,
+		StorageConfidence:  p.StorageConfidence,
+		Network:            p.Network,
+		NetworkConfidence:  p.NetworkConfidence,
+		Warranty:           p.Warranty,
+		WarrantyConfidence: p.WarrantyConfidence,
+	}
+	if p.ID != "" {
+		model.ID = uuid.MustParse(p.ID)
+	}
+	if p.ProductID != "" {
+		model.ProductID = uuid.MustParse(p.ProductID)
+	}
+	return model
+}
+
+type PhoneAttributesModel struct {
+	gorm.Model                   // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ProductID          uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Brand              string    `gorm:"type:varchar(50);index"`
+	BrandConfidence    float64   `gorm:"type:decimal(3,2);not null;default:0"`
+	ModelName          string    `gorm:"type:varchar(100);index"`
+	ModelConfidence    float64   `gorm:"type:decimal(3,2);not null;default:0"`
+	RamGB              *float64  `gorm:"type:decimal(8,2);index"`
+	RamConfidence      float64   `gorm:"type:decimal(3,2);not null;default:0"`
+	StorageGB          *float64  `gorm:"type:decimal(8,2);index"`
+	StorageConfidence  float64   `gorm:"type:decimal(3,2);not null;default:0"`
+	Network            string    `gorm:"type:varchar(20);index"`
+	NetworkConfidence  float64   `gorm:"type:decimal(3,2);not null;default:0"`
+	Warranty           string    `gorm:"type:varchar(20);index"`
+	WarrantyConfidence float64   `gorm:"type:decimal(3,2);not null;default:0"`
+}
+
+func (PhoneAttributesModel) TableName() string {
+	return "phone_attributes"
+}
+
+func (p PhoneAttributesModel) ToEntity() PhoneAttributes {
+	return PhoneAttributes{
+		ID:                 p.ID.String(),
+		ProductID:          p.ProductID.String(),
+		Brand:              p.Brand,
+		BrandConfidence:    p.BrandConfidence,
+		ModelName:          p.ModelName,
+		ModelConfidence:    p.ModelConfidence,
+		RamGB:              p.RamGB,
+		RamConfidence:      p.RamConfidence,
+		StorageGB:          p.StorageGB,
+		StorageConfidence:  p.StorageConfidence,
+		Network:            p.Network,
+		NetworkConfidence:  p.NetworkConfidence,
+		Warranty:           p.Warranty,
+		WarrantyConfidence: p.WarrantyConfidence,
+	}
+}
@@ -1,43 +1,180 @@
 package entity
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-// Used across layer except Persistence
+// Used across layer except Persistence.
+// Every scrape stores a new product row, so rows sharing a Url form the
+// snapshot history of a product.
 type Product struct {
-	ID          string
-	Name        string
-	Description string
-	ImageLink   string
-	Price       string
-	Rating      float32
-	StoreName   string
+	ID              string
+	Url             string
+	Name            string
+	Description     string
+	DescriptionHTML string // sanitized markup, Description keeps the text with its line breaks
+	ImageLink       string
+	Price           Money
+	OriginalPrice   Money // price before discount, zero when not discounted
+	DiscountPercent float32
+	Rating          float32
+	ShopID          string
+	Shop            Shop // filled when scraping, not loaded back from persistence
+	CategoryID      string
+	// Categories is the breadcrumb ordered from root to leaf. Loaded back from
+	// persistence it only holds the names.
+	Categories []Category
+	SKU        string
+	// Counts keep the text shown on the page, e.g. "Terjual 1rb+",
+	// next to its lower bound, e.g. 1000
+	SoldCountText   string
+	SoldCount       int64
+	ReviewCountText string
+	ReviewCount     int64
+	// Stock keeps the text shown on the page, e.g. "Sisa 3" or "Stok habis", next to the parsed quantity
+	StockText    string
+	Stock        int64
+	Availability Availability
+	MinOrder     int64
+	IsPreOrder   bool
+	PreOrderText string
+	// Promotions running when the snapshot was taken. Price is what the buyer
+	// pays now, CampaignPrice the price of a campaign such as a Flash Sale.
+	CashbackText      string
+	CashbackPercent   float32
+	FreeShipping      bool // Bebas Ongkir
+	CampaignName      string
+	CampaignPrice     Money
+	CampaignEndsAt    *time.Time
+	CampaignQuotaText string
+	CampaignQuota     int64 // items left at the campaign price
+	Wholesale         []WholesaleTier
+	Variants          []ProductVariant
+	Images            []ProductImage
+	Specs             []ProductSpec
+	Phone             *PhoneAttributes // parsed for phones only
+	Extraction        FieldExtractions
 }
 
 func (p Product) ToModel() ProductModel {
-	return ProductModel{
-		ID:          uuid.MustParse(p.ID),
-		Name:        p.Name,
-		Description: p.Description,
-		ImageLink:   p.ImageLink,
-		Price:       p.Price,
-		Rating:      p.Rating,
-		StoreName:   p.StoreName,
+	variants := make([]ProductVariantModel, 0, len(p.Variants))
+	for _, variant := range p.Variants {
+		variants = append(variants, variant.ToModel())
+	}
+	images := make([]ProductImageModel, 0, len(p.Images))
+	for _, image := range p.Images {
+		images = append(images, image.ToModel())
+	}
+	specs := make([]ProductSpecModel, 0, len(p.Specs))
+	for _, spec := range p.Specs {
+		specs = append(specs, spec.ToModel())
+	}
+	wholesale := make([]WholesaleTierModel, 0, len(p.Wholesale))
+	for _, tier := range p.Wholesale {
+		wholesale = append(wholesale, tier.ToModel())
+	}
+
+	var phone *PhoneAttributesModel
+	if p.Phone != nil {
+		model := p.Phone.ToModel()
+		phone = &model
 	}
+
+	model := ProductModel{
+		ID:                uuid.MustParse(p.ID),
+		Url:               p.Url,
+		Name:              p.Name,
+		Description:       p.Description,
+		DescriptionHTML:   p.DescriptionHTML,
+		ImageLink:         p.ImageLink,
+		Price:             p.Price,
+		OriginalPrice:     p.OriginalPrice,
+		DiscountPercent:   p.DiscountPercent,
+		Rating:            p.Rating,
+		CategoryPath:      CategoryPath(p.Categories),
+		SKU:               p.SKU,
+		SoldCountText:     p.SoldCountText,
+		SoldCount:         p.SoldCount,
+		ReviewCountText:   p.ReviewCountText,
+		ReviewCount:       p.ReviewCount,
+		StockText:         p.StockText,
+		Stock:             p.Stock,
+		Availability:      p.Availability,
+		MinOrder:          p.MinOrder,
+		IsPreOrder:        p.IsPreOrder,
+		PreOrderText:      p.PreOrderText,
+		CashbackText:      p.CashbackText,
+		CashbackPercent:   p.CashbackPercent,
+		FreeShipping:      p.FreeShipping,
+		CampaignName:      p.CampaignName,
+		CampaignPrice:     p.CampaignPrice,
+		CampaignEndsAt:    p.CampaignEndsAt,
+		CampaignQuotaText: p.CampaignQuotaText,
+		CampaignQuota:     p.CampaignQuota,
+		Wholesale:         wholesale,
+		Variants:          variants,
+		Images:            images,
+		Specs:             specs,
+		Phone:             phone,
+		Extraction:        p.Extraction,
+	}
+	if p.ShopID != "" {
+		shopID := uuid.MustParse(p.ShopID)
+		model.ShopID = &shopID
+	}
+	if p.CategoryID != "" {
+		categoryID := uuid.MustParse(p.CategoryID)
+		model.CategoryID = &categoryID
+	}
+	return model
 }
 
 // Used in by Gorm
 type ProductModel struct {
-	gorm.Model            // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
-	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
-	Name        string    `gorm:"type:varchar(100);not null"`
-	Description string    `gorm:"type:text;not null"`
-	ImageLink   string    `gorm:"type:text;not null"`
-	Price       string    `gorm:"type:varchar(100);not null"`
-	Rating      float32   `gorm:"type:decimal(10,2)"`
-	StoreName   string    `gorm:"type:varchar(100);not null"`
+	gorm.Model                     // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID                uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4()"`
+	Url               string       `gorm:"type:text;index"`
+	Name              string       `gorm:"type:varchar(100);not null"`
+	Description       string       `gorm:"type:text;not null"`
+	DescriptionHTML   string       `gorm:"type:text"`
+	ImageLink         string       `gorm:"type:text;not null"`
+	Price             Money        `gorm:"embedded;embeddedPrefix:price_"`
+	OriginalPrice     Money        `gorm:"embedded;embeddedPrefix:original_price_"`
+	DiscountPercent   float32      `gorm:"type:decimal(5,2)"`
+	Rating            float32      `gorm:"type:decimal(10,2)"`
+	ShopID            *uuid.UUID   `gorm:"type:uuid;index"`
+	Shop              ShopModel    `gorm:"foreignKey:ShopID;references:ID"`
+	CategoryID        *uuid.UUID   `gorm:"type:uuid;index"`
+	CategoryPath      string       `gorm:"type:text"` // leaf category path, e.g. "Handphone & Tablet > Handphone"
+	SKU               string       `gorm:"type:varchar(100)"`
+	SoldCountText     string       `gorm:"type:varchar(50)"`
+	SoldCount         int64        `gorm:"type:bigint"`
+	ReviewCountText   string       `gorm:"type:varchar(50)"`
+	ReviewCount       int64        `gorm:"type:bigint"`
+	StockText         string       `gorm:"type:varchar(100)"`
+	Stock             int64        `gorm:"type:bigint"`
+	Availability      Availability `gorm:"type:varchar(20)"`
+	MinOrder          int64        `gorm:"type:bigint"`
+	IsPreOrder        bool         `gorm:"type:boolean;not null;default:false"`
+	PreOrderText      string       `gorm:"type:varchar(100)"`
+	CashbackText      string       `gorm:"type:varchar(100)"`
+	CashbackPercent   float32      `gorm:"type:decimal(5,2)"`
+	FreeShipping      bool         `gorm:"type:boolean;not null;default:false"`
+	CampaignName      string       `gorm:"type:varchar(100)"`
+	CampaignPrice     Money        `gorm:"embedded;embeddedPrefix:campaign_price_"`
+	CampaignEndsAt    *time.Time
+	CampaignQuotaText string                `gorm:"type:varchar(100)"`
+	CampaignQuota     int64                 `gorm:"type:bigint"`
+	Wholesale         []WholesaleTierModel  `gorm:"foreignKey:ProductID;references:ID"`
+	Variants          []ProductVariantModel `gorm:"foreignKey:ProductID;references:ID"`
+	Images            []ProductImageModel   `gorm:"foreignKey:ProductID;references:ID"`
+	Specs             []ProductSpecModel    `gorm:"foreignKey:ProductID;references:ID"`
+	Phone             *PhoneAttributesModel `gorm:"foreignKey:ProductID;references:ID"`
+	Extraction        FieldExtractions      `gorm:"type:jsonb"` // per-field extraction status
 }
 
 // TableName overrides the table name used by ProductModel to `products`
@@ -47,13 +184,82 @@ func (ProductModel) TableName() string {
 
 // ToDomain converts the persistence model to the domain entity
 func (p ProductModel) ToEntity() Product {
+	var shopID string
+	if p.ShopID != nil {
+		shopID = p.ShopID.String()
+	}
+	var categoryID string
+	if p.CategoryID != nil {
+		categoryID = p.CategoryID.String()
+	}
+	var categories []Category
+	if p.CategoryPath != "" {
+		for _, name := range strings.Split(p.CategoryPath, CategoryPathSeparator) {
+			categories = append(categories, Category{Name: name})
+		}
+	}
+
+	variants := make([]ProductVariant, 0, len(p.Variants))
+	for _, variant := range p.Variants {
+		variants = append(variants, variant.ToEntity())
+	}
+	images := make([]ProductImage, 0, len(p.Images))
+	for _, image := range p.Images {
+		images = append(images, image.ToEntity())
+	}
+	specs := make([]ProductSpec, 0, len(p.Specs))
+	for _, spec := range p.Specs {
+		specs = append(specs, spec.ToEntity())
+	}
+	wholesale := make([]WholesaleTier, 0, len(p.Wholesale))
+	for _, tier := range p.Wholesale {
+		wholesale = append(wholesale, tier.ToEntity())
+	}
+
+	var phone *PhoneAttributes
+	if p.Phone != nil {
+		attributes := p.Phone.ToEntity()
+		phone = &attributes
+	}
+
 	return Product{
-		ID:          p.ID.String(),
-		Name:        p.Name,
-		Description: p.Description,
-		ImageLink:   p.ImageLink,
-		Price:       p.Price,
-		Rating:      p.Rating,
-		StoreName:   p.StoreName,
+		ID:                p.ID.String(),
+		ShopID:            shopID,
+		CategoryID:        categoryID,
+		Categories:        categories,
+		Url:               p.Url,
+		Name:              p.Name,
+		Description:       p.Description,
+		DescriptionHTML:   p.DescriptionHTML,
+		ImageLink:         p.ImageLink,
+		Price:             p.Price,
+		OriginalPrice:     p.OriginalPrice,
+		DiscountPercent:   p.DiscountPercent,
+		Rating:            p.Rating,
+		SKU:               p.SKU,
+		SoldCountText:     p.SoldCountText,
+		SoldCount:         p.SoldCount,
+		ReviewCountText:   p.ReviewCountText,
+		ReviewCount:       p.ReviewCount,
+		StockText:         p.StockText,
+		Stock:             p.Stock,
+		Availability:      p.Availability,
+		MinOrder:          p.MinOrder,
+		IsPreOrder:        p.IsPreOrder,
+		PreOrderText:      p.PreOrderText,
+		CashbackText:      p.CashbackText,
+		CashbackPercent:   p.CashbackPercent,
+		FreeShipping:      p.FreeShipping,
+		CampaignName:      p.CampaignName,
+		CampaignPrice:     p.CampaignPrice,
+		CampaignEndsAt:    p.CampaignEndsAt,
+		CampaignQuotaText: p.CampaignQuotaText,
+		CampaignQuota:     p.CampaignQuota,
+		Wholesale:         wholesale,
+		Variants:          variants,
+		Images:            images,
+		Specs:             specs,
+		Phone:             phone,
+		Extraction:        p.Extraction,
 	}
 }
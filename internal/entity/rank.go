@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RankEntry is the organic rank of a product in a seed listing during one
+// discovery run. Entries of a run share its RunAt, so consecutive runs of a
+// seed give the rank trajectory of each product.
+type RankEntry struct {
+	ID        string
+	Seed      string
+	Url       string // product url without query, so runs line up
+	RunAt     time.Time
+	Rank      int // position among the organic cards across pages, from 1
+	PageIndex int
+	Position  int // card position on the page, from 1
+}
+
+func (r RankEntry) ToModel() RankEntryModel {
+	model := RankEntryModel{
+		Seed:      r.Seed,
+		Url:       r.Url,
+		RunAt:     r.RunAt,
+		Rank:      r.Rank,
+		PageIndex: r.PageIndex,
+		Position:  r.Position,
+	}
+	if r.ID != "" {
+		model.ID = uuid.MustParse(r.ID)
+	}
+	return model
+}
+
+type RankEntryModel struct {
+	gorm.Model           // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	Seed       string    `gorm:"type:varchar(100);not null;index:idx_rank_history_seed_run"`
+	Url        string    `gorm:"type:text;not null;index"`
+	RunAt      time.Time `gorm:"not null;index:idx_rank_history_seed_run"`
+	Rank       int       `gorm:"type:int;not null"`
+	PageIndex  int       `gorm:"type:int;not null"`
+	Position   int       `gorm:"type:int;not null"`
+}
+
+func (RankEntryModel) TableName() string {
+	return "rank_history"
+}
+
+func (r RankEntryModel) ToEntity() RankEntry {
+	return RankEntry{
+		ID:        r.ID.String(),
+		Seed:      r.Seed,
+		Url:       r.Url,
+		RunAt:     r.RunAt,
+		Rank:      r.Rank,
+		PageIndex: r.PageIndex,
+		Position:  r.Position,
+	}
+}
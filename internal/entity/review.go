@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Review is a customer review of a product. Reviews are stored once per
+// Tokopedia review ID, linked to the product snapshot they were first seen on.
+type Review struct {
+	ID             string
+	ProductID      string
+	ProductUrl     string
+	ExternalID     string // Tokopedia review ID
+	Rating         int    // 1 to 5 stars
+	Text           string
+	ReviewedAt     *time.Time
+	ReviewedAtText string // as shown on the page, e.g. "2 minggu lalu"
+	Variant        string // variant bought, e.g. "Hitam, 8/256GB"
+	HelpfulCount   int64
+	HasMedia       bool // photos or videos attached
+}
+
+func (r Review) ToModel() ReviewModel {
+	model := ReviewModel{
+		ProductUrl:     r.ProductUrl,
+		ExternalID:     r.ExternalID,
+		Rating:         r.Rating,
+		Text:           r.Text,
+		ReviewedAt:     r.ReviewedAt,
+		ReviewedAtText: r.ReviewedAtText,
+		Variant:        r.Variant,
+		HelpfulCount:   r.HelpfulCount,
+		HasMedia:       r.HasMedia,
+	}
+	if r.ID != "" {
+		model.ID = uuid.MustParse(r.ID)
+	}
+	if r.ProductID != "" {
+		model.ProductID = uuid.MustParse(r.ProductID)
+	}
+	return model
+}
+
+type ReviewModel struct {
+	gorm.Model               // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID             uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ProductID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	ProductUrl     string    `gorm:"type:text;not null;index"`
+	ExternalID     string    `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Rating         int       `gorm:"type:smallint;not null"`
+	Text           string    `gorm:"type:text;not null"`
+	ReviewedAt     *time.Time
+	ReviewedAtText string `gorm:"type:varchar(50)"`
+	Variant        string `gorm:"type:varchar(255)"`
+	HelpfulCount   int64  `gorm:"type:bigint"`
+	HasMedia       bool   `gorm:"type:boolean;not null;default:false"`
+}
+
+func (ReviewModel) TableName() string {
+	return "reviews"
+}
+
+func (r ReviewModel) ToEntity() Review {
+	return Review{
+		ID:             r.ID.String(),
+		ProductID:      r.ProductID.String(),
+		ProductUrl:     r.ProductUrl,
+		ExternalID:     r.ExternalID,
+		Rating:         r.Rating,
+		Text:           r.Text,
+		ReviewedAt:     r.ReviewedAt,
+		ReviewedAtText: r.ReviewedAtText,
+		Variant:        r.Variant,
+		HelpfulCount:   r.HelpfulCount,
+		HasMedia:       r.HasMedia,
+	}
+}
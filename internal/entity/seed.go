@@ -0,0 +1,26 @@
+package entity
+
+// SeedSet is the seed file, see files/config/seeds.yaml
+type SeedSet struct {
+	Seeds []Seed `yaml:"seeds"`
+}
+
+// Seed is a listing product links are discovered from, e.g. a category or a search
+type Seed struct {
+	Name string `yaml:"name"`
+	// Url is the listing url with a %d placeholder for the page index
+	Url string `yaml:"url"`
+	// DiscussionPages is the number of discussion (Q&A) pages scraped per
+	// product of this seed. It costs extra page loads, 0 skips discussions.
+	DiscussionPages int `yaml:"discussion_pages"`
+}
+
+// Seed returns the seed with the given name
+func (s SeedSet) Seed(name string) (Seed, bool) {
+	for _, seed := range s.Seeds {
+		if seed.Name == name {
+			return seed, true
+		}
+	}
+	return Seed{}, false
+}
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SelectorSet is the declarative selector file, see files/config/selectors.yaml
+type SelectorSet struct {
+	Version string                   `yaml:"version"`
+	Sites   map[string]SiteSelectors `yaml:"sites"`
+}
+
+type SiteSelectors struct {
+	Pages map[string]PageSelectors `yaml:"pages"`
+}
+
+// PageSelectors holds the fields extracted from a single page type, e.g. the product detail page
+type PageSelectors struct {
+	// Container, when set, selects repeated elements such as listing cards.
+	// Fields are then read once per container, relative to it.
+	Container string          `yaml:"container"`
+	Fields    []FieldSelector `yaml:"fields"`
+	// Elements are repeated or clickable elements read by dedicated code,
+	// e.g. the detail list. Only their DOM alternatives are used, and every
+	// element matching the first alternative that matches is read.
+	Elements []FieldSelector `yaml:"elements"`
+}
+
+// Element returns the element of the given name, without alternatives when
+// the page has none
+func (p PageSelectors) Element(name string) FieldSelector {
+	for _, element := range p.Elements {
+		if element.Name == name {
+			return element
+		}
+	}
+	return FieldSelector{Name: name}
+}
+
+// FieldSelector describes how to extract one field from a page
+type FieldSelector struct {
+	Name string `yaml:"name"`
+	// Selector is a shorthand for a single css alternative, tried before Selectors
+	Selector  string                `yaml:"selector"`
+	Selectors []SelectorAlternative `yaml:"selectors"`
+	// Attribute is read instead of the text content. The pseudo attributes
+	// ":html" and ":text" read sanitized HTML and text keeping line breaks.
+	Attribute  string   `yaml:"attribute"`
+	Transforms []string `yaml:"transforms"`
+	Required   bool     `yaml:"required"`
+	// XHR, JSONLD and State are paths into the page's structured data, tried
+	// in that order before the DOM. XHR is "OperationName:path" into a GraphQL
+	// response captured while the page loaded.
+	XHR    string `yaml:"xhr"`
+	JSONLD string `yaml:"jsonld"`
+	State  string `yaml:"state"`
+}
+
+// SelectorAlternative is one way of locating a field. Exactly one strategy is set.
+type SelectorAlternative struct {
+	Name  string `yaml:"name"`  // label used in match telemetry
+	CSS   string `yaml:"css"`   // css selector
+	XPath string `yaml:"xpath"` // xpath expression
+	Text  string `yaml:"text"`  // text anchor, the value is read from the element right after the label
+}
+
+// Alternatives returns every selector alternative of the field in the order
+// they are tried. Alternatives without a name are labelled by strategy and position.
+func (f FieldSelector) Alternatives() []SelectorAlternative {
+	var alternatives []SelectorAlternative
+	if f.Selector != "" {
+		alternatives = append(alternatives, SelectorAlternative{CSS: f.Selector})
+	}
+	alternatives = append(alternatives, f.Selectors...)
+
+	for i := range alternatives {
+		if alternatives[i].Name != "" {
+			continue
+		}
+		alternatives[i].Name = fmt.Sprintf("%s#%d", alternatives[i].Strategy(), i)
+	}
+	return alternatives
+}
+
+// Strategy returns the name of the strategy set on the alternative
+func (a SelectorAlternative) Strategy() string {
+	switch {
+	case a.CSS != "":
+		return "css"
+	case a.XPath != "":
+		return "xpath"
+	case a.Text != "":
+		return "text"
+	}
+	return ""
+}
+
+// FieldValue is the result of extracting a FieldSelector from a page
+type FieldValue struct {
+	Name  string
+	Value string
+	// Matched is the name of the alternative that produced the value,
+	// MatchIndex its position, -1 when nothing matched
+	Matched    string
+	MatchIndex int
+	Source     string // xhr, jsonld, state or dom
+	Err        error
+}
+
+// Operations returns the GraphQL operation names referenced by xhr paths
+func (p PageSelectors) Operations() []string {
+	seen := make(map[string]bool)
+	var operations []string
+	for _, field := range p.Fields {
+		operation, _, ok := strings.Cut(field.XHR, ":")
+		if !ok || seen[operation] {
+			continue
+		}
+		seen[operation] = true
+		operations = append(operations, operation)
+	}
+	return operations
+}
+
+// Operations returns the GraphQL operation names referenced by xhr paths of every page type
+func (s SiteSelectors) Operations() []string {
+	seen := make(map[string]bool)
+	var operations []string
+	for _, page := range s.Pages {
+		for _, operation := range page.Operations() {
+			if !seen[operation] {
+				seen[operation] = true
+				operations = append(operations, operation)
+			}
+		}
+	}
+	return operations
+}
+
+// Site returns the selectors of every page type of a site
+func (s SelectorSet) Site(site string) (SiteSelectors, error) {
+	siteSelectors, ok := s.Sites[site]
+	if !ok {
+		return SiteSelectors{}, fmt.Errorf("no selectors for site %q", site)
+	}
+	return siteSelectors, nil
+}
+
+// Page returns the selectors of a page type of a site
+func (s SelectorSet) Page(site, page string) (PageSelectors, error) {
+	siteSelectors, err := s.Site(site)
+	if err != nil {
+		return PageSelectors{}, err
+	}
+	pageSelectors, ok := siteSelectors.Pages[page]
+	if !ok {
+		return PageSelectors{}, fmt.Errorf("no selectors for page %q of site %q", page, site)
+	}
+	return pageSelectors, nil
+}
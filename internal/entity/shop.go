@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ShopBadge string
+
+const (
+	ShopBadgeNone             ShopBadge = "none"
+	ShopBadgeOfficialStore    ShopBadge = "official_store"
+	ShopBadgePowerMerchant    ShopBadge = "power_merchant"
+	ShopBadgePowerMerchantPro ShopBadge = "power_merchant_pro"
+)
+
+// Shop is a Tokopedia seller, identified by its domain, e.g. "samsung-official"
+// for https://www.tokopedia.com/samsung-official
+type Shop struct {
+	ID           string
+	ExternalID   string // Tokopedia shop ID, empty when unknown
+	Domain       string
+	Name         string
+	City         string
+	Badge        ShopBadge
+	Rating       float32
+	ResponseTime string // as shown on the page, e.g. "± 1 jam"
+	JoinedAt     *time.Time
+	ScrapedAt    *time.Time // last visit of the shop page, nil when never visited
+}
+
+func (s Shop) ToModel() ShopModel {
+	model := ShopModel{
+		ExternalID:   s.ExternalID,
+		Domain:       s.Domain,
+		Name:         s.Name,
+		City:         s.City,
+		Badge:        s.Badge,
+		Rating:       s.Rating,
+		ResponseTime: s.ResponseTime,
+		JoinedAt:     s.JoinedAt,
+		ScrapedAt:    s.ScrapedAt,
+	}
+	if s.ID != "" {
+		model.ID = uuid.MustParse(s.ID)
+	}
+	return model
+}
+
+type ShopModel struct {
+	gorm.Model             // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ExternalID   string    `gorm:"type:varchar(50);index"`
+	Domain       string    `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Name         string    `gorm:"type:varchar(100);not null"`
+	City         string    `gorm:"type:varchar(100)"`
+	Badge        ShopBadge `gorm:"type:varchar(30)"`
+	Rating       float32   `gorm:"type:decimal(10,2)"`
+	ResponseTime string    `gorm:"type:varchar(50)"`
+	JoinedAt     *time.Time
+	ScrapedAt    *time.Time
+}
+
+func (ShopModel) TableName() string {
+	return "shops"
+}
+
+func (s ShopModel) ToEntity() Shop {
+	return Shop{
+		ID:           s.ID.String(),
+		ExternalID:   s.ExternalID,
+		Domain:       s.Domain,
+		Name:         s.Name,
+		City:         s.City,
+		Badge:        s.Badge,
+		Rating:       s.Rating,
+		ResponseTime: s.ResponseTime,
+		JoinedAt:     s.JoinedAt,
+		ScrapedAt:    s.ScrapedAt,
+	}
+}
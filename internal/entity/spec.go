@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Product conditions
+const (
+	ConditionNew  = "new"
+	ConditionUsed = "used"
+)
+
+// Where a spec was found
+const (
+	SpecSourceDetail      = "detail"      // the detail list of the product page
+	SpecSourceDescription = "description" // a "Key: value" line of the description, a hint only
+)
+
+// ProductSpec is one entry of the product detail list, e.g. "Berat Satuan: 200 g"
+type ProductSpec struct {
+	ID        string
+	ProductID string
+	Key       string   // normalised key, e.g. weight
+	Label     string   // label as shown on the page, e.g. Berat Satuan
+	RawValue  string   // value as shown on the page, e.g. 200 g
+	Value     string   // normalised value, e.g. the condition enum
+	Number    *float64 // numeric value in Unit when it could be parsed, e.g. weight in grams
+	Unit      string
+	Source    string
+}
+
+func (s ProductSpec) ToModel() ProductSpecModel {
+	model := ProductSpecModel{
+		Key:      s.Key,
+		Label:    s.Label,
+		RawValue: s.RawValue,
+		Value:    s.Value,
+		Number:   s.Number,
+		Unit:     s.Unit,
+		Source:   s.Source,
+	}
+	if s.ID != "" {
+		model.ID = uuid.MustParse(s.ID)
+	}
+	if s.ProductID != "" {
+		model.ProductID = uuid.MustParse(s.ProductID)
+	}
+	return model
+}
+
+type ProductSpecModel struct {
+	gorm.Model           // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ProductID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Key        string    `gorm:"type:varchar(100);not null;index"`
+	Label      string    `gorm:"type:varchar(100);not null"`
+	RawValue   string    `gorm:"type:text;not null"`
+	Value      string    `gorm:"type:text;not null"`
+	Number     *float64  `gorm:"type:decimal(20,4)"`
+	Unit       string    `gorm:"type:varchar(20)"`
+	Source     string    `gorm:"type:varchar(20);not null;default:detail"`
+}
+
+func (ProductSpecModel) TableName() string {
+	return "product_specs"
+}
+
+func (s ProductSpecModel) ToEntity() ProductSpec {
+	return ProductSpec{
+		ID:        s.ID.String(),
+		ProductID: s.ProductID.String(),
+		Key:       s.Key,
+		Label:     s.Label,
+		RawValue:  s.RawValue,
+		Value:     s.Value,
+		Number:    s.Number,
+		Unit:      s.Unit,
+		Source:    s.Source,
+	}
+}
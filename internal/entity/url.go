@@ -8,6 +8,7 @@ import (
 type Url struct {
 	ID         string
 	Url        string
+	Seed       string // name of the seed the url was discovered from
 	IsScrapped bool
 }
 
@@ -15,6 +16,7 @@ func (url Url) ToModel() UrlModel {
 	return UrlModel{
 		ID:         uuid.MustParse(url.ID),
 		Url:        url.Url,
+		Seed:       url.Seed,
 		IsScrapped: url.IsScrapped,
 	}
 }
@@ -23,6 +25,7 @@ type UrlModel struct {
 	gorm.Model           // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
 	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
 	Url        string    `gorm:"type:text;not null"`
+	Seed       string    `gorm:"type:varchar(100);index"`
 	IsScrapped bool      `gorm:"type:boolean;not null"`
 }
 
@@ -34,6 +37,7 @@ func (url UrlModel) ToEntity() Url {
 	return Url{
 		ID:         url.ID.String(),
 		Url:        url.Url,
+		Seed:       url.Seed,
 		IsScrapped: url.IsScrapped,
 	}
 }
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"database/sql/driver"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VariantOption is one dimension of a variant, e.g. Warna: Hitam
+type VariantOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// VariantOptions is stored as a jsonb column
+type VariantOptions []VariantOption
+
+func (o VariantOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return "[]", nil
+	}
+	return jsonValue(o)
+}
+
+func (o *VariantOptions) Scan(value interface{}) error {
+	*o = nil
+	return scanJSON(value, o)
+}
+
+// ProductVariant is one combination of options, e.g. Hitam / 8GB+256GB, with its own price and stock
+type ProductVariant struct {
+	ID        string
+	ProductID string
+	Options   VariantOptions
+	SKU       string
+	Price     Money
+	Stock     int64
+}
+
+func (v ProductVariant) ToModel() ProductVariantModel {
+	model := ProductVariantModel{
+		Options: v.Options,
+		SKU:     v.SKU,
+		Price:   v.Price,
+		Stock:   v.Stock,
+	}
+	if v.ID != "" {
+		model.ID = uuid.MustParse(v.ID)
+	}
+	if v.ProductID != "" {
+		model.ProductID = uuid.MustParse(v.ProductID)
+	}
+	return model
+}
+
+type ProductVariantModel struct {
+	gorm.Model                // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID         uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ProductID  uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Options    VariantOptions `gorm:"type:jsonb;not null"`
+	SKU        string         `gorm:"type:varchar(100)"`
+	Price      Money          `gorm:"embedded;embeddedPrefix:price_"`
+	Stock      int64          `gorm:"type:bigint"`
+}
+
+func (ProductVariantModel) TableName() string {
+	return "product_variants"
+}
+
+func (v ProductVariantModel) ToEntity() ProductVariant {
+	return ProductVariant{
+		ID:        v.ID.String(),
+		ProductID: v.ProductID.String(),
+		Options:   v.Options,
+		SKU:       v.SKU,
+		Price:     v.Price,
+		Stock:     v.Stock,
+	}
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WholesaleTier is one "harga grosir" price tier, the unit price when buying
+// at least MinQty items
+type WholesaleTier struct {
+	ID        string
+	ProductID string
+	MinQty    int64
+	MaxQty    int64 // 0 for the last, open ended tier
+	Price     Money
+}
+
+func (w WholesaleTier) ToModel() WholesaleTierModel {
+	model := WholesaleTierModel{
+		MinQty: w.MinQty,
+		MaxQty: w.MaxQty,
+		Price:  w.Price,
+	}
+	if w.ID != "" {
+		model.ID = uuid.MustParse(w.ID)
+	}
+	if w.ProductID != "" {
+		model.ProductID = uuid.MustParse(w.ProductID)
+	}
+	return model
+}
+
+type WholesaleTierModel struct {
+	gorm.Model           // Embeds fields like ID, CreatedAt, UpdatedAt, DeletedAt
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()"`
+	ProductID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	MinQty     int64     `gorm:"type:bigint;not null"`
+	MaxQty     int64     `gorm:"type:bigint"`
+	Price      Money     `gorm:"embedded;embeddedPrefix:price_"`
+}
+
+func (WholesaleTierModel) TableName() string {
+	return "product_wholesale_prices"
+}
+
+func (w WholesaleTierModel) ToEntity() WholesaleTier {
+	return WholesaleTier{
+		ID:        w.ID.String(),
+		ProductID: w.ProductID.String(),
+		MinQty:    w.MinQty,
+		MaxQty:    w.MaxQty,
+		Price:     w.Price,
+	}
+}
@@ -0,0 +1,63 @@
+package category
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CategoryRepo struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *CategoryRepo {
+	return &CategoryRepo{
+		db: db,
+	}
+}
+
+// UpsertCategoryPath stores every level of a breadcrumb ordered from root to
+// leaf, linking each level to its parent, and returns the stored categories
+func (cr CategoryRepo) UpsertCategoryPath(ctx context.Context, inputs []entity.Category) ([]entity.Category, error) {
+	output := make([]entity.Category, 0, len(inputs))
+
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		parentID := ""
+		for i, input := range inputs {
+			input.ID = uuid.New().String()
+			input.ParentID = parentID
+			input.Level = i + 1
+			input.Path = entity.CategoryPath(inputs[:i+1])
+			model := input.ToModel()
+
+			// The DOM breadcrumb has no category ID, keep the one a richer source stored
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "path"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"external_id": gorm.Expr("COALESCE(NULLIF(EXCLUDED.external_id, ''), categories.external_id)"),
+					"url":         gorm.Expr("COALESCE(NULLIF(EXCLUDED.url, ''), categories.url)"),
+					"updated_at":  gorm.Expr("EXCLUDED.updated_at"),
+				}),
+			}).Create(&model).Error
+			if err != nil {
+				return err
+			}
+
+			// On conflict the generated ID is not the stored one, read it back
+			var stored entity.CategoryModel
+			if err := tx.Where("path = ?", input.Path).First(&stored).Error; err != nil {
+				return err
+			}
+			output = append(output, stored.ToEntity())
+			parentID = stored.ID.String()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
@@ -3,14 +3,30 @@ package csv
 import (
 	"context"
 	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/indragunawan95/topedcrawler/internal/entity"
 )
 
+// productHeader is the header of the product csv, rows follow its column order
+var productHeader = []string{"Url", "Name", "Description", "Category", "StoreName", "ShopDomain", "ShopCity", "ShopBadge", "Price", "Currency", "OriginalPrice", "DiscountPercent", "Rating", "ReviewCount", "SoldCount", "Stock", "Availability", "MinOrder", "PreOrder", "Cashback", "FreeShipping", "CampaignName", "CampaignPrice", "CampaignEndsAt", "CampaignQuota", "Wholesale", "PhoneBrand", "PhoneModel", "PhoneRamGB", "PhoneStorageGB", "PhoneNetwork", "PhoneWarranty", "PhoneConfidence", "ImageLink", "Images", "Variants"}
+
 type CSVRepository struct {
 	filePath string
+
+	// Workers append concurrently, rows and the header check must not interleave
+	mu            sync.Mutex
+	headerChecked bool
 }
 
 func New(filePath string) *CSVRepository {
@@ -18,6 +34,16 @@ func New(filePath string) *CSVRepository {
 }
 
 func (r *CSVRepository) SaveProductsToCSV(ctx context.Context, products []entity.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.headerChecked {
+		if err := rotateOnHeaderChange(r.filePath, productHeader, time.Now()); err != nil {
+			return err
+		}
+		r.headerChecked = true
+	}
+
 	// Open the file with append mode and write permissions
 	file, err := os.OpenFile(r.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -35,8 +61,7 @@ func (r *CSVRepository) SaveProductsToCSV(ctx context.Context, products []entity
 	}
 	// If the file is new or empty, write the header
 	if fileInfo.Size() == 0 {
-		header := []string{"Name", "Description", "StoreName", "Price", "Rating", "ImageLink"}
-		if err := writer.Write(header); err != nil {
+		if err := writer.Write(productHeader); err != nil {
 			return err
 		}
 	}
@@ -44,13 +69,39 @@ func (r *CSVRepository) SaveProductsToCSV(ctx context.Context, products []entity
 	// Writing the product data
 	for _, product := range products {
 		record := []string{
+			product.Url,
 			product.Name,
 			product.Description,
-			product.StoreName,
-			product.Price,
+			entity.CategoryPath(product.Categories),
+			product.Shop.Name,
+			product.Shop.Domain,
+			product.Shop.City,
+			string(product.Shop.Badge),
+			product.Price.Major(),
+			product.Price.Currency,
+			product.OriginalPrice.Major(),
+			strconv.FormatFloat(float64(product.DiscountPercent), 'f', 2, 32),
 			strconv.FormatFloat(float64(product.Rating), 'f', 2, 32),
-			product.ImageLink,
+			strconv.FormatInt(product.ReviewCount, 10),
+			strconv.FormatInt(product.SoldCount, 10),
+			strconv.FormatInt(product.Stock, 10),
+			string(product.Availability),
+			strconv.FormatInt(product.MinOrder, 10),
+			strconv.FormatBool(product.IsPreOrder),
+			product.CashbackText,
+			strconv.FormatBool(product.FreeShipping),
+			product.CampaignName,
+			product.CampaignPrice.Major(),
+			formatTime(product.CampaignEndsAt),
+			product.CampaignQuotaText,
+			formatWholesale(product.Wholesale),
 		}
+		record = append(record, formatPhone(product.Phone)...)
+		record = append(record,
+			product.ImageLink,
+			formatImages(product.Images),
+			formatVariants(product.Variants),
+		)
 		if err := writer.Write(record); err != nil {
 			return err
 		}
@@ -58,3 +109,135 @@ func (r *CSVRepository) SaveProductsToCSV(ctx context.Context, products []entity
 
 	return nil
 }
+
+// rotateOnHeaderChange moves an existing file whose header differs from
+// header aside, e.g. data.csv to data.20240325T101500.csv, so rows with the
+// new columns are not appended under the old header
+func rotateOnHeaderChange(path string, header []string, now time.Time) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	existing, err := csv.NewReader(file).Read()
+	file.Close()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err == nil && slices.Equal(existing, header) {
+		return nil
+	}
+
+	ext := filepath.Ext(path)
+	rotated := strings.TrimSuffix(path, ext) + "." + now.Format("20060102T150405") + ext
+	if err := os.Rename(path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate %s with an outdated header: %w", path, err)
+	}
+	log.Printf("Moved %s to %s, its header does not match the current columns", path, rotated)
+	return nil
+}
+
+// formatImages renders the gallery urls in order separated by spaces
+func formatImages(images []entity.ProductImage) string {
+	urls := make([]string, 0, len(images))
+	for _, image := range images {
+		urls = append(urls, image.Url)
+	}
+	return strings.Join(urls, " ")
+}
+
+// formatTime renders an optional time as RFC 3339, empty when unknown
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatWholesale renders tiers as "5-9 = 1250000.00" separated by "; ", the last tier as "10+"
+func formatWholesale(tiers []entity.WholesaleTier) string {
+	formatted := make([]string, 0, len(tiers))
+	for _, tier := range tiers {
+		quantity := fmt.Sprintf("%d+", tier.MinQty)
+		if tier.MaxQty > 0 {
+			quantity = fmt.Sprintf("%d-%d", tier.MinQty, tier.MaxQty)
+		}
+		formatted = append(formatted, fmt.Sprintf("%s = %s", quantity, tier.Price.Major()))
+	}
+	return strings.Join(formatted, "; ")
+}
+
+// formatPhone renders the phone columns, the confidences as
+// "brand=0.90 model=0.80 ram=0.90 storage=0.90 network=0.90 warranty=1.00".
+// Products that are not phones get empty columns.
+func formatPhone(phone *entity.PhoneAttributes) []string {
+	if phone == nil {
+		return make([]string, 7)
+	}
+	confidence := fmt.Sprintf("brand=%.2f model=%.2f ram=%.2f storage=%.2f network=%.2f warranty=%.2f",
+		phone.BrandConfidence, phone.ModelConfidence, phone.RamConfidence, phone.StorageConfidence, phone.NetworkConfidence, phone.WarrantyConfidence)
+	return []string{
+		phone.Brand,
+		phone.ModelName,
+		formatGB(phone.RamGB),
+		formatGB(phone.StorageGB),
+		phone.Network,
+		phone.Warranty,
+		confidence,
+	}
+}
+
+// formatGB renders an optional size in GB, empty when unknown
+func formatGB(gb *float64) string {
+	if gb == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*gb, 'f', -1, 64)
+}
+
+// formatVariants renders variants as "Warna: Hitam / Memori: 128GB = 1299000.00 (stock 5)" separated by "; "
+func formatVariants(variants []entity.ProductVariant) string {
+	formatted := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		options := make([]string, 0, len(variant.Options))
+		for _, option := range variant.Options {
+			options = append(options, option.Name+": "+option.Value)
+		}
+		formatted = append(formatted, fmt.Sprintf("%s = %s (stock %d)", strings.Join(options, " / "), variant.Price.Major(), variant.Stock))
+	}
+	return strings.Join(formatted, "; ")
+}
+
+// SaveRankHistoryToCSV writes rank entries to the file, replacing it, one row
+// per product, seed and run
+func (r *CSVRepository) SaveRankHistoryToCSV(ctx context.Context, entries []entity.RankEntry) error {
+	file, err := os.Create(r.filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{"Seed", "Url", "RunAt", "Rank", "PageIndex", "Position"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		record := []string{
+			entry.Seed,
+			entry.Url,
+			entry.RunAt.Format(time.RFC3339),
+			strconv.Itoa(entry.Rank),
+			strconv.Itoa(entry.PageIndex),
+			strconv.Itoa(entry.Position),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package csv
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func readRows(t *testing.T, path string) [][]string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestRotateOnHeaderChange(t *testing.T) {
+	now := time.Date(2024, 3, 25, 10, 15, 0, 0, time.UTC)
+	header := []string{"Url", "Name"}
+
+	tests := []struct {
+		name        string
+		content     *string // nil when the file does not exist
+		wantRotated bool
+	}{
+		{"missing file", nil, false},
+		{"empty file", ptr(""), false},
+		{"same header", ptr("Url,Name\nhttps://a,A\n"), false},
+		{"outdated header", ptr("Url,Name,StoreName\nhttps://a,A,Shop\n"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "data.csv")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := rotateOnHeaderChange(path, header, now); err != nil {
+				t.Fatalf("rotateOnHeaderChange: %v", err)
+			}
+
+			rotated := filepath.Join(dir, "data.20240325T101500.csv")
+			_, err := os.Stat(rotated)
+			if gotRotated := err == nil; gotRotated != tt.wantRotated {
+				t.Errorf("rotated = %v, want %v", gotRotated, tt.wantRotated)
+			}
+			if tt.wantRotated {
+				if _, err := os.Stat(path); !os.IsNotExist(err) {
+					t.Errorf("outdated file still at %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveProductsToCSVKeepsColumnsAligned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("Url,Name,StoreName\nhttps://a,A,Shop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := New(path)
+	product := entity.Product{Url: "https://b", Name: "B", Price: entity.Money{Amount: 100, Currency: entity.CurrencyIDR}}
+	if err := repo.SaveProductsToCSV(context.Background(), []entity.Product{product}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := readRows(t, path)
+	if len(rows) != 2 || !slices.Equal(rows[0], productHeader) {
+		t.Fatalf("rows = %v, want the current header and one product", rows)
+	}
+	if len(rows[1]) != len(productHeader) {
+		t.Errorf("row has %d columns, header %d", len(rows[1]), len(productHeader))
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,90 @@
+package dictionary
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadPhoneDictionary reads and validates the phone attribute dictionary
+func LoadPhoneDictionary(path string) (*entity.PhoneDictionary, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read phone dictionary: %w", err)
+	}
+
+	dict := &entity.PhoneDictionary{}
+	if err := yaml.Unmarshal(b, dict); err != nil {
+		return nil, fmt.Errorf("failed to parse phone dictionary: %w", err)
+	}
+
+	if err := validatePhoneDictionary(dict); err != nil {
+		return nil, fmt.Errorf("invalid phone dictionary %s: %w", path, err)
+	}
+	return dict, nil
+}
+
+func validatePhoneDictionary(dict *entity.PhoneDictionary) error {
+	if len(dict.Categories) == 0 && len(dict.Seeds) == 0 {
+		return fmt.Errorf("no categories or seeds")
+	}
+	if len(dict.Brands) == 0 {
+		return fmt.Errorf("no brands")
+	}
+	seen := make(map[string]bool)
+	for i, brand := range dict.Brands {
+		if brand.Name == "" {
+			return fmt.Errorf("brand %d without name", i)
+		}
+		if seen[brand.Name] {
+			return fmt.Errorf("duplicate brand %q", brand.Name)
+		}
+		seen[brand.Name] = true
+		if len(brand.Aliases) == 0 && len(brand.Series) == 0 {
+			return fmt.Errorf("brand %q without aliases or series", brand.Name)
+		}
+	}
+
+	for i, memory := range dict.Memory {
+		re, err := regexp.Compile(memory.Pattern)
+		if err != nil {
+			return fmt.Errorf("memory pattern %d: %w", i, err)
+		}
+		if memory.Ram == 0 && memory.Storage == 0 {
+			return fmt.Errorf("memory pattern %d reads neither ram nor storage", i)
+		}
+		for _, group := range []int{memory.Ram, memory.Storage, memory.Unit} {
+			if group < 0 || group > re.NumSubexp() {
+				return fmt.Errorf("memory pattern %d has no capture group %d", i, group)
+			}
+		}
+		if err := validateConfidence(memory.Confidence); err != nil {
+			return fmt.Errorf("memory pattern %d: %w", i, err)
+		}
+	}
+
+	for name, patterns := range map[string][]entity.ValuePattern{"network": dict.Networks, "warranty": dict.Warranties} {
+		for i, pattern := range patterns {
+			if pattern.Value == "" {
+				return fmt.Errorf("%s pattern %d without value", name, i)
+			}
+			if _, err := regexp.Compile(pattern.Pattern); err != nil {
+				return fmt.Errorf("%s pattern %q: %w", name, pattern.Value, err)
+			}
+			if err := validateConfidence(pattern.Confidence); err != nil {
+				return fmt.Errorf("%s pattern %q: %w", name, pattern.Value, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateConfidence(confidence float64) error {
+	if confidence <= 0 || confidence > 1 {
+		return fmt.Errorf("confidence %v must be in (0, 1]", confidence)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package discussion
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DiscussionRepo struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *DiscussionRepo {
+	return &DiscussionRepo{
+		db: db,
+	}
+}
+
+// UpsertQuestions stores questions with their answers, de-duplicated by
+// Tokopedia question and answer ID. A stored question gets its answer count
+// refreshed and any answers not stored yet.
+func (dr DiscussionRepo) UpsertQuestions(ctx context.Context, inputs []entity.DiscussionQuestion) error {
+	return dr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, input := range inputs {
+			input.ID = uuid.New().String()
+			model := input.ToModel()
+			answers := model.Answers
+			model.Answers = nil
+
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "external_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"answer_count", "updated_at"}),
+			}).Create(&model).Error
+			if err != nil {
+				return err
+			}
+			if len(answers) == 0 {
+				continue
+			}
+
+			// On conflict the generated ID is not the stored one, read it back
+			var stored entity.DiscussionQuestionModel
+			err = tx.Select("id").Where("external_id = ?", model.ExternalID).First(&stored).Error
+			if err != nil {
+				return err
+			}
+			for i := range answers {
+				answers[i].ID = uuid.New()
+				answers[i].QuestionID = stored.ID
+			}
+
+			err = tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "external_id"}},
+				DoNothing: true,
+			}).Create(&answers).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
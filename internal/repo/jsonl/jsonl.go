@@ -0,0 +1,253 @@
+package jsonl
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// JSONRepository appends products to a JSON Lines file, one product per line
+type JSONRepository struct {
+	filePath string
+}
+
+func New(filePath string) *JSONRepository {
+	return &JSONRepository{filePath: filePath}
+}
+
+type money struct {
+	Amount   int64  `json:"amount"` // minor units
+	Currency string `json:"currency"`
+}
+
+type variant struct {
+	Options []entity.VariantOption `json:"options"`
+	SKU     string                 `json:"sku,omitempty"`
+	Price   money                  `json:"price"`
+	Stock   int64                  `json:"stock"`
+}
+
+type image struct {
+	Position int    `json:"position"`
+	Url      string `json:"url"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+}
+
+type spec struct {
+	Label    string   `json:"label"`
+	RawValue string   `json:"raw_value"`
+	Value    string   `json:"value"`
+	Number   *float64 `json:"number,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+	Source   string   `json:"source"`
+}
+
+type wholesaleTier struct {
+	MinQty int64 `json:"min_qty"`
+	MaxQty int64 `json:"max_qty,omitempty"` // omitted for the last, open ended tier
+	Price  money `json:"price"`
+}
+
+type promo struct {
+	CashbackText      string          `json:"cashback_text,omitempty"`
+	CashbackPercent   float32         `json:"cashback_percent,omitempty"`
+	FreeShipping      bool            `json:"free_shipping"`
+	CampaignName      string          `json:"campaign_name,omitempty"`
+	CampaignPrice     *money          `json:"campaign_price,omitempty"`
+	CampaignEndsAt    *time.Time      `json:"campaign_ends_at,omitempty"`
+	CampaignQuotaText string          `json:"campaign_quota_text,omitempty"`
+	CampaignQuota     int64           `json:"campaign_quota,omitempty"`
+	Wholesale         []wholesaleTier `json:"wholesale"`
+}
+
+// phoneAttribute is a parsed phone attribute and its confidence between 0 and 1
+type phoneAttribute struct {
+	Value      interface{} `json:"value"`
+	Confidence float64     `json:"confidence"`
+}
+
+type phone struct {
+	Brand     *phoneAttribute `json:"brand,omitempty"`
+	Model     *phoneAttribute `json:"model,omitempty"`
+	RamGB     *phoneAttribute `json:"ram_gb,omitempty"`
+	StorageGB *phoneAttribute `json:"storage_gb,omitempty"`
+	Network   *phoneAttribute `json:"network,omitempty"`
+	Warranty  *phoneAttribute `json:"warranty,omitempty"`
+}
+
+type shop struct {
+	ID           string           `json:"id"`
+	ExternalID   string           `json:"external_id,omitempty"`
+	Domain       string           `json:"domain"`
+	Name         string           `json:"name"`
+	City         string           `json:"city,omitempty"`
+	Badge        entity.ShopBadge `json:"badge"`
+	Rating       float32          `json:"rating"`
+	ResponseTime string           `json:"response_time,omitempty"`
+	JoinedAt     *time.Time       `json:"joined_at,omitempty"`
+	ScrapedAt    *time.Time       `json:"scraped_at,omitempty"`
+}
+
+type product struct {
+	ID              string                  `json:"id"`
+	Url             string                  `json:"url"`
+	Name            string                  `json:"name"`
+	Description     string                  `json:"description"`
+	DescriptionHTML string                  `json:"description_html,omitempty"`
+	CategoryID      string                  `json:"category_id,omitempty"`
+	Category        []string                `json:"category"` // root to leaf
+	Shop            shop                    `json:"shop"`
+	Price           money                   `json:"price"`
+	OriginalPrice   *money                  `json:"original_price,omitempty"`
+	DiscountPercent float32                 `json:"discount_percent"`
+	Rating          float32                 `json:"rating"`
+	ReviewCount     int64                   `json:"review_count"`
+	SoldCount       int64                   `json:"sold_count"`
+	SKU             string                  `json:"sku,omitempty"`
+	StockText       string                  `json:"stock_text,omitempty"`
+	Stock           int64                   `json:"stock"`
+	Availability    entity.Availability     `json:"availability,omitempty"`
+	MinOrder        int64                   `json:"min_order"`
+	IsPreOrder      bool                    `json:"is_pre_order"`
+	PreOrderText    string                  `json:"pre_order_text,omitempty"`
+	Promo           promo                   `json:"promo"`
+	Phone           *phone                  `json:"phone,omitempty"` // phones only
+	ImageLink       string                  `json:"image_link"`
+	Images          []image                 `json:"images"`
+	Specs           map[string]spec         `json:"specs"`
+	Variants        []variant               `json:"variants"`
+	Extraction      entity.FieldExtractions `json:"extraction"`
+}
+
+func (r *JSONRepository) SaveProductsToJSON(ctx context.Context, products []entity.Product) error {
+	// Open the file with append mode and write permissions
+	file, err := os.OpenFile(r.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, p := range products {
+		if err := encoder.Encode(toExport(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toExport(p entity.Product) product {
+	out := product{
+		ID:              p.ID,
+		Url:             p.Url,
+		Name:            p.Name,
+		Description:     p.Description,
+		DescriptionHTML: p.DescriptionHTML,
+		CategoryID:      p.CategoryID,
+		Category:        make([]string, 0, len(p.Categories)),
+		Shop:            shop(p.Shop),
+		Price:           money(p.Price),
+		DiscountPercent: p.DiscountPercent,
+		Rating:          p.Rating,
+		ReviewCount:     p.ReviewCount,
+		SoldCount:       p.SoldCount,
+		SKU:             p.SKU,
+		StockText:       p.StockText,
+		Stock:           p.Stock,
+		Availability:    p.Availability,
+		MinOrder:        p.MinOrder,
+		IsPreOrder:      p.IsPreOrder,
+		PreOrderText:    p.PreOrderText,
+		Promo: promo{
+			CashbackText:      p.CashbackText,
+			CashbackPercent:   p.CashbackPercent,
+			FreeShipping:      p.FreeShipping,
+			CampaignName:      p.CampaignName,
+			CampaignEndsAt:    p.CampaignEndsAt,
+			CampaignQuotaText: p.CampaignQuotaText,
+			CampaignQuota:     p.CampaignQuota,
+			Wholesale:         make([]wholesaleTier, 0, len(p.Wholesale)),
+		},
+		ImageLink:  p.ImageLink,
+		Images:     make([]image, 0, len(p.Images)),
+		Specs:      make(map[string]spec, len(p.Specs)),
+		Variants:   make([]variant, 0, len(p.Variants)),
+		Extraction: p.Extraction,
+	}
+	if !p.OriginalPrice.IsZero() {
+		originalPrice := money(p.OriginalPrice)
+		out.OriginalPrice = &originalPrice
+	}
+	if !p.CampaignPrice.IsZero() {
+		campaignPrice := money(p.CampaignPrice)
+		out.Promo.CampaignPrice = &campaignPrice
+	}
+	if p.Phone != nil {
+		out.Phone = toPhone(*p.Phone)
+	}
+	for _, w := range p.Wholesale {
+		out.Promo.Wholesale = append(out.Promo.Wholesale, wholesaleTier{
+			MinQty: w.MinQty,
+			MaxQty: w.MaxQty,
+			Price:  money(w.Price),
+		})
+	}
+	for _, c := range p.Categories {
+		out.Category = append(out.Category, c.Name)
+	}
+	for _, i := range p.Images {
+		out.Images = append(out.Images, image{
+			Position: i.Position,
+			Url:      i.Url,
+			Width:    i.Width,
+			Height:   i.Height,
+		})
+	}
+	for _, sp := range p.Specs {
+		out.Specs[sp.Key] = spec{
+			Label:    sp.Label,
+			RawValue: sp.RawValue,
+			Value:    sp.Value,
+			Number:   sp.Number,
+			Unit:     sp.Unit,
+			Source:   sp.Source,
+		}
+	}
+	for _, v := range p.Variants {
+		out.Variants = append(out.Variants, variant{
+			Options: v.Options,
+			SKU:     v.SKU,
+			Price:   money(v.Price),
+			Stock:   v.Stock,
+		})
+	}
+	return out
+}
+
+// toPhone exports the attributes that were found, unknown ones are omitted
+func toPhone(p entity.PhoneAttributes) *phone {
+	text := func(value string, confidence float64) *phoneAttribute {
+		if value == "" {
+			return nil
+		}
+		return &phoneAttribute{Value: value, Confidence: confidence}
+	}
+	number := func(value *float64, confidence float64) *phoneAttribute {
+		if value == nil {
+			return nil
+		}
+		return &phoneAttribute{Value: *value, Confidence: confidence}
+	}
+	return &phone{
+		Brand:     text(p.Brand, p.BrandConfidence),
+		Model:     text(p.ModelName, p.ModelConfidence),
+		RamGB:     number(p.RamGB, p.RamConfidence),
+		StorageGB: number(p.StorageGB, p.StorageConfidence),
+		Network:   text(p.Network, p.NetworkConfidence),
+		Warranty:  text(p.Warranty, p.WarrantyConfidence),
+	}
+}
@@ -0,0 +1,32 @@
+package listing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gorm.io/gorm"
+)
+
+type ListingRepo struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *ListingRepo {
+	return &ListingRepo{
+		db: db,
+	}
+}
+
+func (lr ListingRepo) CreateListingSnapshots(ctx context.Context, inputs []entity.ListingSnapshot) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	models := make([]entity.ListingSnapshotModel, 0, len(inputs))
+	for _, input := range inputs {
+		input.ID = uuid.New().String()
+		models = append(models, input.ToModel())
+	}
+	return lr.db.WithContext(ctx).Create(&models).Error
+}
@@ -20,6 +20,21 @@ func New(db *gorm.DB) *ProductRepo {
 
 func (pr ProductRepo) CreateProduct(ctx context.Context, input entity.Product) (entity.Product, error) {
 	input.ID = uuid.New().String()
+	for i := range input.Variants {
+		input.Variants[i].ID = uuid.New().String() // Assign a new UUID for each variant
+	}
+	for i := range input.Images {
+		input.Images[i].ID = uuid.New().String()
+	}
+	for i := range input.Specs {
+		input.Specs[i].ID = uuid.New().String()
+	}
+	for i := range input.Wholesale {
+		input.Wholesale[i].ID = uuid.New().String()
+	}
+	if input.Phone != nil {
+		input.Phone.ID = uuid.New().String()
+	}
 	model := input.ToModel()
 
 	err := pr.db.WithContext(ctx).Create(&model).Error
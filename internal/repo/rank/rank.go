@@ -0,0 +1,97 @@
+package rank
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gorm.io/gorm"
+)
+
+type RankRepo struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *RankRepo {
+	return &RankRepo{
+		db: db,
+	}
+}
+
+func (rr RankRepo) CreateRankEntries(ctx context.Context, inputs []entity.RankEntry) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	models := make([]entity.RankEntryModel, 0, len(inputs))
+	for _, input := range inputs {
+		input.ID = uuid.New().String()
+		models = append(models, input.ToModel())
+	}
+	return rr.db.WithContext(ctx).Create(&models).Error
+}
+
+// GetSeeds returns the seeds with rank history
+func (rr RankRepo) GetSeeds(ctx context.Context) ([]string, error) {
+	var seeds []string
+
+	err := rr.db.WithContext(ctx).Model(&entity.RankEntryModel{}).
+		Distinct("seed").Order("seed").Pluck("seed", &seeds).Error
+	if err != nil {
+		return nil, err
+	}
+	return seeds, nil
+}
+
+// GetRunTimes returns the runs of a seed, latest first
+func (rr RankRepo) GetRunTimes(ctx context.Context, seed string) ([]time.Time, error) {
+	var runs []time.Time
+
+	err := rr.db.WithContext(ctx).Model(&entity.RankEntryModel{}).
+		Where("seed = ?", seed).
+		Distinct("run_at").Order("run_at DESC").Pluck("run_at", &runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// GetRankHistory returns the rank entries of a seed ordered by product and
+// run, limited to one product when url is not empty
+func (rr RankRepo) GetRankHistory(ctx context.Context, seed, url string) ([]entity.RankEntry, error) {
+	var models []entity.RankEntryModel
+
+	query := rr.db.WithContext(ctx).Where("seed = ?", seed)
+	if url != "" {
+		query = query.Where("url = ?", url)
+	}
+	err := query.Order("url, run_at").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]entity.RankEntry, 0, len(models))
+	for _, model := range models {
+		output = append(output, model.ToEntity())
+	}
+	return output, nil
+}
+
+// GetRun returns the rank entries of one run of a seed up to maxRank, best rank first
+func (rr RankRepo) GetRun(ctx context.Context, seed string, runAt time.Time, maxRank int) ([]entity.RankEntry, error) {
+	var models []entity.RankEntryModel
+
+	err := rr.db.WithContext(ctx).
+		Where("seed = ? AND run_at = ? AND rank <= ?", seed, runAt, maxRank).
+		Order("rank").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]entity.RankEntry, 0, len(models))
+	for _, model := range models {
+		output = append(output, model.ToEntity())
+	}
+	return output, nil
+}
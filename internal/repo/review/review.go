@@ -0,0 +1,57 @@
+package review
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepo struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *ReviewRepo {
+	return &ReviewRepo{
+		db: db,
+	}
+}
+
+// GetReviewIDs returns the Tokopedia review IDs already stored for a product url
+func (rr ReviewRepo) GetReviewIDs(ctx context.Context, productUrl string) (map[string]bool, error) {
+	var externalIDs []string
+
+	err := rr.db.WithContext(ctx).Model(&entity.ReviewModel{}).
+		Where("product_url = ?", productUrl).
+		Pluck("external_id", &externalIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(externalIDs))
+	for _, id := range externalIDs {
+		ids[id] = true
+	}
+	return ids, nil
+}
+
+// UpsertReviews stores new reviews. A review already stored under the same
+// Tokopedia review ID only gets its helpful count refreshed.
+func (rr ReviewRepo) UpsertReviews(ctx context.Context, inputs []entity.Review) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	models := make([]entity.ReviewModel, 0, len(inputs))
+	for _, input := range inputs {
+		input.ID = uuid.New().String()
+		models = append(models, input.ToModel())
+	}
+
+	return rr.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "external_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"helpful_count", "updated_at"}),
+	}).Create(&models).Error
+}
@@ -0,0 +1,181 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// SourceXHR marks values read from a GraphQL response captured during navigation
+const SourceXHR = "xhr"
+
+// graphqlHost is the endpoint the Tokopedia frontend fetches its data from
+const graphqlHost = "gql.tokopedia.com"
+
+type graphqlRequest struct {
+	OperationName string `json:"operationName"`
+}
+
+// CaptureOperations sets the GraphQL operation names whose responses are
+// captured while a page loads, on top of LayoutOperation, ReviewOperation and DiscussionOperation.
+func (s *ScrapperRepo) CaptureOperations(names ...string) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+
+	s.operations = map[string]bool{LayoutOperation: true, ReviewOperation: true, DiscussionOperation: true}
+	for _, name := range names {
+		s.operations[name] = true
+	}
+}
+
+// pageCapture holds the GraphQL payloads captured on one tab. Responses are
+// tagged with the navigation they belong to, so a late response of the
+// previous page is dropped instead of mixed into the current one.
+type pageCapture struct {
+	mu         sync.Mutex
+	settled    *sync.Cond // signalled when no response body is being read
+	navigation int
+	reading    int
+	captured   map[string]interface{}
+}
+
+func newPageCapture() *pageCapture {
+	c := &pageCapture{captured: make(map[string]interface{})}
+	c.settled = sync.NewCond(&c.mu)
+	return c
+}
+
+// reset starts a new navigation and drops what was captured so far
+func (c *pageCapture) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.navigation++
+	c.captured = make(map[string]interface{})
+}
+
+// begin registers a response whose body is about to be read
+func (c *pageCapture) begin() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reading++
+	return c.navigation
+}
+
+// finish stores the payloads read for navigation, nil when reading failed
+func (c *pageCapture) finish(navigation int, payloads map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reading--
+	if navigation == c.navigation {
+		for name, payload := range payloads {
+			c.captured[name] = payload
+		}
+	}
+	if c.reading == 0 {
+		c.settled.Broadcast()
+	}
+}
+
+// snapshot waits for the bodies being read and copies the captured payloads
+func (c *pageCapture) snapshot() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.reading > 0 {
+		c.settled.Wait()
+	}
+
+	captured := make(map[string]interface{}, len(c.captured))
+	for name, payload := range c.captured {
+		captured[name] = payload
+	}
+	return captured
+}
+
+// CapturedResponses returns the captured GraphQL payloads of the current page
+// keyed by operation name. It waits for responses still being read.
+func (s *ScrapperRepo) CapturedResponses() map[string]interface{} {
+	if s.capture == nil {
+		return map[string]interface{}{}
+	}
+	return s.capture.snapshot()
+}
+
+// onResponse is the response listener of one tab. Playwright calls listeners
+// synchronously from its dispatch loop, so the body is read in a goroutine.
+func (s *ScrapperRepo) onResponse(capture *pageCapture, response playwright.Response) {
+	if !strings.Contains(response.URL(), graphqlHost) || !response.Ok() {
+		return
+	}
+	names := s.wantedOperations(response.Request())
+	if len(names) == 0 {
+		return
+	}
+
+	navigation := capture.begin()
+	go func() {
+		capture.finish(navigation, readPayloads(response, names))
+	}()
+}
+
+// wantedOperations returns the operation names of a (possibly batched)
+// GraphQL request, with an empty name for operations we do not capture
+func (s *ScrapperRepo) wantedOperations(request playwright.Request) []string {
+	postData, err := request.PostData()
+	if err != nil || postData == "" {
+		return nil
+	}
+
+	var batch []graphqlRequest
+	if err := json.Unmarshal([]byte(postData), &batch); err != nil {
+		var single graphqlRequest
+		if err := json.Unmarshal([]byte(postData), &single); err != nil {
+			return nil
+		}
+		batch = []graphqlRequest{single}
+	}
+
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+
+	wanted := false
+	names := make([]string, len(batch))
+	for i, operation := range batch {
+		if s.operations[operation.OperationName] {
+			names[i] = operation.OperationName
+			wanted = true
+		}
+	}
+	if !wanted {
+		return nil
+	}
+	return names
+}
+
+// readPayloads returns the payload of each wanted operation. Batched
+// responses are arrays in the same order as the request.
+func readPayloads(response playwright.Response, names []string) map[string]interface{} {
+	body, err := response.Body()
+	if err != nil {
+		return nil
+	}
+
+	var payloads []interface{}
+	if err := json.Unmarshal(body, &payloads); err != nil {
+		var single interface{}
+		if err := json.Unmarshal(body, &single); err != nil {
+			return nil
+		}
+		payloads = []interface{}{single}
+	}
+
+	out := make(map[string]interface{}, len(names))
+	for i, name := range names {
+		if name == "" || i >= len(payloads) {
+			continue
+		}
+		out[name] = payloads[i]
+	}
+	return out
+}
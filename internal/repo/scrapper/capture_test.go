@@ -0,0 +1,48 @@
+package scrapper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPageCaptureDropsStaleNavigation(t *testing.T) {
+	c := newPageCapture()
+	stale := c.begin()
+	c.reset()
+	current := c.begin()
+
+	c.finish(stale, map[string]interface{}{LayoutOperation: "previous page"})
+	c.finish(current, map[string]interface{}{ReviewOperation: "current page"})
+
+	got := c.snapshot()
+	if _, ok := got[LayoutOperation]; ok {
+		t.Errorf("snapshot kept the payload of the previous navigation: %v", got)
+	}
+	if got[ReviewOperation] != "current page" {
+		t.Errorf("snapshot = %v, want the payload of the current navigation", got)
+	}
+}
+
+func TestPageCaptureSnapshotWaitsForReads(t *testing.T) {
+	c := newPageCapture()
+	navigation := c.begin()
+
+	done := make(chan map[string]interface{})
+	go func() { done <- c.snapshot() }()
+
+	select {
+	case <-done:
+		t.Fatal("snapshot returned while a body was still being read")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	c.finish(navigation, map[string]interface{}{LayoutOperation: "layout"})
+	select {
+	case got := <-done:
+		if got[LayoutOperation] != "layout" {
+			t.Errorf("snapshot = %v, want the layout payload", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("snapshot did not return after the read finished")
+	}
+}
@@ -0,0 +1,136 @@
+package scrapper
+
+import (
+	"fmt"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// cardsScript reads the raw value of every alternative of every field of
+// every card in one round trip. It returns cards[card][field][alternative],
+// null where the alternative matched nothing. Locating follows locate: css
+// relative to the card, ":scope" being the card itself, xpath relative to the
+// card, and text the element right after an exact label.
+const cardsScript = `(cards, fields) => {
+	const html = ` + sanitizeHTMLScript + `;
+	const text = ` + structuredTextScript + `;
+	const normalize = (s) => (s || '').replace(/\s+/g, ' ').trim();
+	const locate = (card, alternative) => {
+		if (alternative.css) {
+			return alternative.css === ':scope' ? card : card.querySelector(alternative.css);
+		}
+		if (alternative.xpath) {
+			const path = alternative.xpath.startsWith('/') ? '.' + alternative.xpath : alternative.xpath;
+			return document.evaluate(path, card, null, XPathResult.FIRST_ORDERED_NODE_TYPE, null).singleNodeValue;
+		}
+		if (alternative.text) {
+			const label = [card, ...card.querySelectorAll('*')].reverse()
+				.find(el => normalize(el.textContent) === alternative.text);
+			return label ? label.nextElementSibling : null;
+		}
+		return null;
+	};
+	const read = (el, attribute) => {
+		if (!el) return null;
+		switch (attribute) {
+		case '': return el.textContent;
+		case '` + AttributeHTML + `': return html(el);
+		case '` + AttributeText + `': return text(el);
+		}
+		return el.getAttribute(attribute);
+	};
+	return cards.map(card => fields.map(field =>
+		field.alternatives.map(alternative => read(locate(card, alternative), field.attribute))));
+}`
+
+// ExtractCards reads the fields of every element matching the container
+// selector of a page, e.g. every product card of a listing, in document
+// order. Card fields are read from the DOM only, relative to their card,
+// with a single script for all cards.
+func (s *ScrapperRepo) ExtractCards(page entity.PageSelectors) ([][]entity.FieldValue, error) {
+	fields := make([]map[string]interface{}, 0, len(page.Fields))
+	for _, field := range page.Fields {
+		var alternatives []map[string]string
+		for _, alternative := range field.Alternatives() {
+			alternatives = append(alternatives, map[string]string{
+				"css":   alternative.CSS,
+				"xpath": alternative.XPath,
+				"text":  alternative.Text,
+			})
+		}
+		fields = append(fields, map[string]interface{}{
+			"attribute":    field.Attribute,
+			"alternatives": alternatives,
+		})
+	}
+
+	raw, err := s.page.Locator(page.Container).EvaluateAll(cardsScript, fields)
+	if err != nil {
+		return nil, classifyLocatorError(page.Container, err)
+	}
+	cards, err := parseCards(raw, page.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, newScrapeError("read cards", page.Container, ErrSelectorMissing, nil)
+	}
+	return cards, nil
+}
+
+// parseCards turns the result of cardsScript into field values. Like
+// extractField, the first alternative with a non-empty value after the
+// transforms wins.
+func parseCards(raw interface{}, fields []entity.FieldSelector) ([][]entity.FieldValue, error) {
+	list, ok := raw.([]interface{})
+	if raw != nil && !ok {
+		return nil, fmt.Errorf("unexpected cards result %T", raw)
+	}
+
+	cards := make([][]entity.FieldValue, 0, len(list))
+	for _, c := range list {
+		card, _ := c.([]interface{})
+		values := make([]entity.FieldValue, 0, len(fields))
+		for i, field := range fields {
+			var read []interface{}
+			if i < len(card) {
+				read, _ = card[i].([]interface{})
+			}
+			values = append(values, cardField(field, read))
+		}
+		cards = append(cards, values)
+	}
+	return cards, nil
+}
+
+func cardField(field entity.FieldSelector, read []interface{}) entity.FieldValue {
+	result := entity.FieldValue{Name: field.Name, MatchIndex: -1}
+
+	var lastErr error
+	for i, alternative := range field.Alternatives() {
+		var value string
+		if i < len(read) {
+			value, _ = read[i].(string)
+		}
+		value, err := applyTransforms(value, field.Transforms)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if value == "" {
+			lastErr = newScrapeError("read element", alternative.Name, ErrSelectorMissing, nil)
+			continue
+		}
+		result.Value = value
+		result.Source = SourceDOM
+		result.Matched = alternative.Name
+		result.MatchIndex = i
+		return result
+	}
+
+	result.Err = lastErr
+	if result.Err == nil {
+		result.Err = newScrapeError("read element", field.Name, ErrSelectorMissing, nil)
+	}
+	return result
+}
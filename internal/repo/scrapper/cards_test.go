@@ -0,0 +1,61 @@
+package scrapper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestParseCards(t *testing.T) {
+	fields := []entity.FieldSelector{
+		{Name: "url", Selector: ":scope", Attribute: "href", Required: true},
+		{
+			Name:       "price",
+			Selector:   "[data-testid='spnSRPProdPrice']",
+			Selectors:  []entity.SelectorAlternative{{Name: "prd-price", CSS: ".prd_link-product-price"}},
+			Transforms: []string{"trim"},
+		},
+		{Name: "ad_badge", Selector: "[data-testid='lblProductAds']", Transforms: []string{"trim"}},
+	}
+	raw := mustJSON(t, `[
+		[["https://www.tokopedia.com/a/b"], ["Rp5.499.000", null], [null]],
+		[["https://www.tokopedia.com/c/d"], ["  ", " Rp99.000 "], ["Ad"]]
+	]`)
+
+	cards, err := parseCards(raw, fields)
+	if err != nil {
+		t.Fatalf("parseCards: %v", err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("parseCards read %d cards, want 2", len(cards))
+	}
+
+	first, second := cards[0], cards[1]
+	if first[0].Value != "https://www.tokopedia.com/a/b" || first[0].Source != SourceDOM {
+		t.Errorf("url = %+v", first[0])
+	}
+	if first[1].Value != "Rp5.499.000" || first[1].MatchIndex != 0 {
+		t.Errorf("primary price = %+v", first[1])
+	}
+	if !errors.Is(first[2].Err, ErrSelectorMissing) || first[2].MatchIndex != -1 {
+		t.Errorf("missing ad badge = %+v, want ErrSelectorMissing", first[2])
+	}
+	// A blank primary falls back to the next alternative
+	if second[1].Value != "Rp99.000" || second[1].Matched != "prd-price" || second[1].MatchIndex != 1 {
+		t.Errorf("fallback price = %+v", second[1])
+	}
+	if second[2].Value != "Ad" {
+		t.Errorf("ad badge = %+v", second[2])
+	}
+}
+
+func TestParseCardsEmpty(t *testing.T) {
+	cards, err := parseCards(mustJSON(t, `[]`), nil)
+	if err != nil || len(cards) != 0 {
+		t.Errorf("parseCards([]) = %v, %v, want no cards", cards, err)
+	}
+	if _, err := parseCards(mustJSON(t, `{"cards": []}`), nil); err == nil {
+		t.Error("parseCards of an object returned no error")
+	}
+}
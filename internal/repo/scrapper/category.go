@@ -0,0 +1,135 @@
+package scrapper
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+const breadcrumbScript = `(links) => links.map(a => ({
+	name: a.textContent || '',
+	url: a.href || ''
+}))`
+
+// categoryPathPrefix is the path of Tokopedia category pages, e.g.
+// https://www.tokopedia.com/p/handphone-tablet/handphone. Breadcrumb entries
+// outside it, like Home and the product itself, are not categories.
+const categoryPathPrefix = "/p/"
+
+// GetProductCategories returns the breadcrumb of the current page ordered from
+// root to leaf. It reads the category of the captured layout response, then
+// the JSON-LD BreadcrumbList, then the DOM breadcrumb whose links the element
+// matches.
+func (s *ScrapperRepo) GetProductCategories(element entity.FieldSelector) ([]entity.Category, error) {
+	data := s.loadStructuredData()
+
+	for _, source := range []interface{}{s.CapturedResponses()[LayoutOperation], data.state} {
+		detail, ok := resolve(source, []string{"**", "basicInfo", "category", "detail"}).([]interface{})
+		if !ok {
+			continue
+		}
+		var categories []entity.Category
+		for _, d := range detail {
+			item, _ := d.(map[string]interface{})
+			category := entity.Category{}
+			category.ExternalID, _ = scalarString(item["id"])
+			category.Name, _ = scalarString(item["name"])
+			category.Url, _ = scalarString(item["breadcrumbURL"])
+			categories = appendCategory(categories, category)
+		}
+		if len(categories) > 0 {
+			return categories, nil
+		}
+	}
+
+	if breadcrumb, ok := data.breadcrumb.(map[string]interface{}); ok {
+		if categories := parseBreadcrumbList(breadcrumb); len(categories) > 0 {
+			return categories, nil
+		}
+	}
+
+	locator, match := s.findElements(element)
+	if match.Err != nil {
+		return nil, match.Err
+	}
+	raw, err := locator.EvaluateAll(breadcrumbScript)
+	if err != nil {
+		return nil, classifyLocatorError(match.Matched, err)
+	}
+	links, _ := raw.([]interface{})
+	var categories []entity.Category
+	for _, l := range links {
+		link, _ := l.(map[string]interface{})
+		name, _ := link["name"].(string)
+		href, _ := link["url"].(string)
+		if isCategoryURL(href) {
+			categories = appendCategory(categories, entity.Category{Name: name, Url: href})
+		}
+	}
+	if len(categories) == 0 {
+		return nil, newScrapeError("read categories", match.Matched, ErrSelectorMissing, nil)
+	}
+	return categories, nil
+}
+
+// parseBreadcrumbList reads
+//
+//	{"@type": "BreadcrumbList", "itemListElement": [{"position": 2, "name": "Handphone", "item": "https://..."}]}
+//
+// in position order, keeping only category entries
+func parseBreadcrumbList(breadcrumb map[string]interface{}) []entity.Category {
+	elements, _ := breadcrumb["itemListElement"].([]interface{})
+	type positioned struct {
+		position int
+		category entity.Category
+	}
+	var items []positioned
+	for i, e := range elements {
+		element, _ := e.(map[string]interface{})
+		position := i
+		if p, ok := scalarString(element["position"]); ok {
+			if parsed, err := strconv.Atoi(p); err == nil {
+				position = parsed
+			}
+		}
+		category := entity.Category{}
+		category.Name, _ = scalarString(element["name"])
+		// item is either the url or an object with @id and name
+		switch item := element["item"].(type) {
+		case string:
+			category.Url = item
+		case map[string]interface{}:
+			category.Url, _ = scalarString(item["@id"])
+			if category.Name == "" {
+				category.Name, _ = scalarString(item["name"])
+			}
+		}
+		if isCategoryURL(category.Url) {
+			items = append(items, positioned{position, category})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].position < items[j].position })
+
+	var categories []entity.Category
+	for _, item := range items {
+		categories = appendCategory(categories, item.category)
+	}
+	return categories
+}
+
+// appendCategory appends a category with a trimmed, non-empty name
+func appendCategory(categories []entity.Category, category entity.Category) []entity.Category {
+	category.Name = strings.Join(strings.Fields(category.Name), " ")
+	if category.Name == "" {
+		return categories
+	}
+	return append(categories, category)
+}
+
+func isCategoryURL(link string) bool {
+	parsed, err := url.Parse(link)
+	return err == nil && strings.HasPrefix(parsed.Path, categoryPathPrefix)
+}
@@ -0,0 +1,78 @@
+package scrapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestParseBreadcrumbList(t *testing.T) {
+	tests := []struct {
+		name       string
+		breadcrumb string
+		want       []string // category names, root first
+	}{
+		{
+			"url items in position order",
+			`{"@type": "BreadcrumbList", "itemListElement": [
+				{"position": 3, "name": "Handphone", "item": "https://www.tokopedia.com/p/handphone-tablet/handphone"},
+				{"position": 1, "name": "Home", "item": "https://www.tokopedia.com/"},
+				{"position": 2, "name": "Handphone & Tablet", "item": "https://www.tokopedia.com/p/handphone-tablet"}
+			]}`,
+			[]string{"Handphone & Tablet", "Handphone"},
+		},
+		{
+			"object items named by the item",
+			`{"@type": "BreadcrumbList", "itemListElement": [
+				{"position": "1", "item": {"@id": "https://www.tokopedia.com/p/handphone-tablet", "name": "Handphone & Tablet"}},
+				{"position": "2", "item": {"@id": "https://www.tokopedia.com/p/handphone-tablet/handphone", "name": " Handphone "}}
+			]}`,
+			[]string{"Handphone & Tablet", "Handphone"},
+		},
+		{
+			"missing positions keep the list order",
+			`{"@type": "BreadcrumbList", "itemListElement": [
+				{"name": "Handphone & Tablet", "item": "https://www.tokopedia.com/p/handphone-tablet"},
+				{"name": "Handphone", "item": "https://www.tokopedia.com/p/handphone-tablet/handphone"}
+			]}`,
+			[]string{"Handphone & Tablet", "Handphone"},
+		},
+		{
+			"product and unnamed entries are dropped",
+			`{"@type": "BreadcrumbList", "itemListElement": [
+				{"position": 1, "name": "", "item": "https://www.tokopedia.com/p/handphone-tablet"},
+				{"position": 2, "name": "Galaxy A54", "item": "https://www.tokopedia.com/samsung-official/galaxy-a54"}
+			]}`,
+			nil,
+		},
+		{"no elements", `{"@type": "BreadcrumbList"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breadcrumb, _ := mustJSON(t, tt.breadcrumb).(map[string]interface{})
+			got := categoryNames(parseBreadcrumbList(breadcrumb))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBreadcrumbList = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBreadcrumbListUrls(t *testing.T) {
+	breadcrumb, _ := mustJSON(t, `{"itemListElement": [
+		{"position": 1, "item": {"@id": "https://www.tokopedia.com/p/handphone-tablet", "name": "Handphone & Tablet"}}
+	]}`).(map[string]interface{})
+	got := parseBreadcrumbList(breadcrumb)
+	if len(got) != 1 || got[0].Url != "https://www.tokopedia.com/p/handphone-tablet" {
+		t.Errorf("parseBreadcrumbList = %+v, want the @id as url", got)
+	}
+}
+
+func categoryNames(categories []entity.Category) []string {
+	var names []string
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+	return names
+}
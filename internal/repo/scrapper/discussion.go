@@ -0,0 +1,119 @@
+package scrapper
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// DiscussionOperation is the GraphQL query the discussion (Q&A) page loads
+// each page of questions with. Like reviews, discussions are read from the
+// captured response because only it carries the question and answer IDs.
+const DiscussionOperation = "discussionDataByProductID"
+
+var discussionResponseURL = regexp.MustCompile(regexp.QuoteMeta(graphqlHost) + `/.*` + DiscussionOperation)
+
+// OpenDiscussionPage opens the discussion page of a product and waits for its
+// first page of questions to be captured
+func (s *ScrapperRepo) OpenDiscussionPage(url string) error {
+	return s.openPageExpecting(url, discussionResponseURL)
+}
+
+// GetProductDiscussions returns the questions of the current discussion page,
+// each with the answers shown under it, and whether there is a next page.
+// A page without a captured discussion response returns ErrSelectorMissing.
+func (s *ScrapperRepo) GetProductDiscussions() ([]entity.DiscussionQuestion, bool, error) {
+	payload, ok := s.CapturedResponses()[DiscussionOperation]
+	if !ok {
+		return nil, false, newScrapeError("read discussions", DiscussionOperation, ErrSelectorMissing, nil)
+	}
+	data, ok := resolve(payload, []string{"**", DiscussionOperation}).(map[string]interface{})
+	if !ok {
+		return nil, false, newScrapeError("read discussions", DiscussionOperation, ErrSelectorMissing, nil)
+	}
+
+	items, _ := data["question"].([]interface{})
+	questions := make([]entity.DiscussionQuestion, 0, len(items))
+	for _, i := range items {
+		item, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if question, ok := parseQuestion(item); ok {
+			questions = append(questions, question)
+		}
+	}
+	hasNext, _ := data["hasNext"].(bool)
+	return questions, hasNext, nil
+}
+
+// NextDiscussionPage clicks the pagination button the element matches and waits
+// for the next page of questions. It returns ErrSelectorMissing on the last page.
+func (s *ScrapperRepo) NextDiscussionPage(element entity.FieldSelector) error {
+	return s.nextPage(element, discussionResponseURL)
+}
+
+// parseQuestion reads one entry of the question list, e.g.
+//
+//	{questionID: "1", content: "Ready gan?", userName: "Budi", createTime: "2024-02-01T10:00:00Z",
+//	 createTimeFormatted: "3 hari lalu", totalAnswer: 1,
+//	 answer: {answerID: "2", content: "Ready kak", userName: "Toko", isSeller: true, ...}}
+//
+// answer is a single object on the list page, an array on some layouts
+func parseQuestion(item map[string]interface{}) (entity.DiscussionQuestion, bool) {
+	id, ok := scalarString(item["questionID"])
+	if !ok || id == "" {
+		return entity.DiscussionQuestion{}, false
+	}
+
+	question := entity.DiscussionQuestion{ExternalID: id}
+	question.Text, _ = scalarString(item["content"])
+	question.AskedBy, _ = scalarString(item["userName"])
+	question.AskedAt = parseDiscussionTime(item["createTime"])
+	question.AskedAtText, _ = scalarString(item["createTimeFormatted"])
+	if total, ok := scalarString(item["totalAnswer"]); ok {
+		question.AnswerCount, _ = strconv.ParseInt(total, 10, 64)
+	}
+
+	answers, ok := item["answer"].([]interface{})
+	if !ok && item["answer"] != nil {
+		answers = []interface{}{item["answer"]}
+	}
+	for _, a := range answers {
+		answer, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		answerID, ok := scalarString(answer["answerID"])
+		if !ok || answerID == "" || answerID == "0" {
+			continue
+		}
+		parsed := entity.DiscussionAnswer{ExternalID: answerID}
+		parsed.Text, _ = scalarString(answer["content"])
+		parsed.AnsweredBy, _ = scalarString(answer["userName"])
+		parsed.IsSeller, _ = answer["isSeller"].(bool)
+		parsed.AnsweredAt = parseDiscussionTime(answer["createTime"])
+		parsed.AnsweredAtText, _ = scalarString(answer["createTimeFormatted"])
+		question.Answers = append(question.Answers, parsed)
+	}
+	return question, true
+}
+
+// parseDiscussionTime reads an RFC 3339 time or unix seconds
+func parseDiscussionTime(value interface{}) *time.Time {
+	text, ok := scalarString(value)
+	if !ok || text == "" {
+		return nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, text); err == nil {
+		parsed = parsed.UTC()
+		return &parsed
+	}
+	if seconds, err := strconv.ParseInt(text, 10, 64); err == nil && seconds > 0 {
+		parsed := time.Unix(seconds, 0).UTC()
+		return &parsed
+	}
+	return nil
+}
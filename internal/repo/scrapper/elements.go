@@ -0,0 +1,48 @@
+package scrapper
+
+import (
+	"strconv"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"github.com/playwright-community/playwright-go"
+)
+
+// LocateElements reports the alternative of every element matching the
+// current page, the value is the number of elements it matches
+func (s *ScrapperRepo) LocateElements(elements []entity.FieldSelector) []entity.FieldValue {
+	values := make([]entity.FieldValue, 0, len(elements))
+	for _, element := range elements {
+		_, value := s.findElements(element)
+		values = append(values, value)
+	}
+	return values
+}
+
+// findElements returns every element of the first alternative matching the
+// current page. Like a field, the primary alternative is waited for briefly
+// and the fallbacks are not.
+func (s *ScrapperRepo) findElements(element entity.FieldSelector) (playwright.Locator, entity.FieldValue) {
+	result := entity.FieldValue{Name: element.Name, MatchIndex: -1}
+	root := s.page.Locator("html")
+	for i, alternative := range element.Alternatives() {
+		locator := locateAll(root, alternative)
+		if i == 0 {
+			waitAttached(locator)
+		}
+		count, err := locator.Count()
+		if err != nil {
+			result.Err = classifyLocatorError(alternative.Name, err)
+			return nil, result
+		}
+		if count == 0 {
+			continue
+		}
+		result.Value = strconv.Itoa(count)
+		result.Source = SourceDOM
+		result.Matched = alternative.Name
+		result.MatchIndex = i
+		return locator, result
+	}
+	result.Err = newScrapeError("read element", element.Name, ErrSelectorMissing, nil)
+	return nil, result
+}
@@ -0,0 +1,88 @@
+package scrapper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Sentinel errors describing why a scrapper operation failed.
+// Callers should branch on them with errors.Is.
+var (
+	ErrNavigationTimeout = errors.New("navigation timeout")
+	ErrNotFound          = errors.New("page not found")
+	ErrSelectorMissing   = errors.New("selector missing")
+	ErrBlocked           = errors.New("blocked or captcha page")
+	ErrBrowserCrashed    = errors.New("browser crashed")
+)
+
+// ScrapeError is returned by ScrapperRepo methods. Kind is one of the
+// sentinel errors above and Err is the underlying Playwright error, if any.
+type ScrapeError struct {
+	Op     string // operation that failed, e.g. "open page"
+	Target string // url or selector the operation was working on
+	Kind   error
+	Err    error
+}
+
+func (e *ScrapeError) Error() string {
+	msg := fmt.Sprintf("%s %s: %v", e.Op, e.Target, e.Kind)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap lets errors.Is match both the sentinel kind and the underlying error.
+func (e *ScrapeError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+func newScrapeError(op, target string, kind, err error) *ScrapeError {
+	return &ScrapeError{
+		Op:     op,
+		Target: target,
+		Kind:   kind,
+		Err:    err,
+	}
+}
+
+// classifyNavigationError maps a Playwright error raised while loading a page
+// to a ScrapeError.
+func classifyNavigationError(url string, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case isBrowserClosed(err):
+		return newScrapeError("open page", url, ErrBrowserCrashed, err)
+	case errors.Is(err, playwright.TimeoutError):
+		return newScrapeError("open page", url, ErrNavigationTimeout, err)
+	}
+	return err
+}
+
+// classifyLocatorError maps a Playwright error raised while reading an element
+// to a ScrapeError. A locator that times out means the element never showed up.
+func classifyLocatorError(selector string, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case isBrowserClosed(err):
+		return newScrapeError("read element", selector, ErrBrowserCrashed, err)
+	case errors.Is(err, playwright.TimeoutError):
+		return newScrapeError("read element", selector, ErrSelectorMissing, err)
+	}
+	return err
+}
+
+func isBrowserClosed(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "has been closed") || strings.Contains(msg, "crashed")
+}
@@ -0,0 +1,181 @@
+package scrapper
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// galleryScript returns the image candidates of every gallery element in document order.
+// Lazy loaded images keep their real url in data-src.
+const galleryScript = `(elements) => elements.map(img => ({
+	src: img.getAttribute('data-src') || img.getAttribute('src') || '',
+	srcset: img.getAttribute('srcset') || ''
+}))`
+
+// measureScript loads every url and reports its natural size, 0 when it fails to load in time
+const measureScript = `(urls) => Promise.all(urls.map(url => new Promise(resolve => {
+	const img = new Image();
+	const timer = setTimeout(() => resolve([0, 0]), 5000);
+	img.onload = () => { clearTimeout(timer); resolve([img.naturalWidth, img.naturalHeight]); };
+	img.onerror = () => { clearTimeout(timer); resolve([0, 0]); };
+	img.src = url;
+})))`
+
+// cacheSegment is the resized variant in Tokopedia CDN urls, e.g.
+// https://images.tokopedia.net/img/cache/100-square/VqbcmM/2023/1/1/a.jpg
+var cacheSegment = regexp.MustCompile(`/img/cache/[^/]+/`)
+
+// GetProductImages returns the gallery of the current page in order, each
+// image at the highest resolution available, and the source used. The
+// element matches the gallery images. It falls back to the JSON-LD images
+// when the gallery is not rendered.
+func (s *ScrapperRepo) GetProductImages(element entity.FieldSelector) ([]entity.ProductImage, string, error) {
+	var urls []string
+	source := SourceDOM
+
+	var candidates []interface{}
+	if locator, match := s.findElements(element); match.Err == nil {
+		raw, err := locator.EvaluateAll(galleryScript)
+		if err != nil {
+			return nil, "", classifyLocatorError(match.Matched, err)
+		}
+		candidates, _ = raw.([]interface{})
+	}
+	for _, c := range candidates {
+		candidate, _ := c.(map[string]interface{})
+		src, _ := candidate["src"].(string)
+		srcset, _ := candidate["srcset"].(string)
+		if url := largestImage(src, srcset); url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	if len(urls) == 0 {
+		urls = jsonLDImages(s.loadStructuredData().product)
+		source = SourceJSONLD
+	}
+
+	urls = uniqueOriginals(urls)
+	if len(urls) == 0 {
+		return nil, "", newScrapeError("read images", element.Name, ErrSelectorMissing, nil)
+	}
+
+	images := make([]entity.ProductImage, 0, len(urls))
+	for i, url := range urls {
+		images = append(images, entity.ProductImage{Position: i, Url: url})
+	}
+	s.measureImages(images)
+	return images, source, nil
+}
+
+// jsonLDImages reads the "image" of a schema.org Product, a url, a list of
+// urls or ImageObjects with a url
+func jsonLDImages(product interface{}) []string {
+	object, ok := product.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	images, ok := object["image"].([]interface{})
+	if !ok {
+		images = []interface{}{object["image"]}
+	}
+
+	var urls []string
+	for _, image := range images {
+		switch v := image.(type) {
+		case string:
+			urls = append(urls, v)
+		case map[string]interface{}:
+			if url, ok := v["url"].(string); ok {
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+// measureImages fills in the natural size of the images, leaving zero when unknown
+func (s *ScrapperRepo) measureImages(images []entity.ProductImage) {
+	urls := make([]string, 0, len(images))
+	for _, image := range images {
+		urls = append(urls, image.Url)
+	}
+
+	raw, err := s.page.Evaluate(measureScript, urls)
+	if err != nil {
+		return
+	}
+	sizes, _ := raw.([]interface{})
+	for i, size := range sizes {
+		dimensions, _ := size.([]interface{})
+		if i >= len(images) || len(dimensions) != 2 {
+			continue
+		}
+		images[i].Width = toInt(dimensions[0])
+		images[i].Height = toInt(dimensions[1])
+	}
+}
+
+// largestImage picks the widest candidate of a srcset, falling back to src
+func largestImage(src, srcset string) string {
+	type candidate struct {
+		url   string
+		width float64
+	}
+	var candidates []candidate
+	for _, entry := range strings.Split(srcset, ",") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		width := 0.0
+		if len(fields) > 1 {
+			// descriptors are either widths ("700w") or densities ("2x")
+			descriptor := fields[1]
+			value, _ := strconv.ParseFloat(strings.TrimRight(descriptor, "wx"), 64)
+			width = value
+			if strings.HasSuffix(descriptor, "x") {
+				width = value * 1000
+			}
+		}
+		candidates = append(candidates, candidate{url: fields[0], width: width})
+	}
+	if len(candidates) == 0 {
+		return strings.TrimSpace(src)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].width > candidates[j].width })
+	return candidates[0].url
+}
+
+// uniqueOriginals rewrites CDN urls to the original upload and drops repeats,
+// the main image is usually also the first thumbnail
+func uniqueOriginals(urls []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, url := range urls {
+		if url == "" || strings.HasPrefix(url, "data:") {
+			continue
+		}
+		url = cacheSegment.ReplaceAllString(url, "/img/")
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+		out = append(out, url)
+	}
+	return out
+}
+
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,59 @@
+package scrapper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONLDImages(t *testing.T) {
+	tests := []struct {
+		name    string
+		product string
+		want    []string
+	}{
+		{"list", `{"image": ["https://a.jpg", "https://b.jpg"]}`, []string{"https://a.jpg", "https://b.jpg"}},
+		{"single url", `{"image": "https://a.jpg"}`, []string{"https://a.jpg"}},
+		{"image objects", `{"image": [{"@type": "ImageObject", "url": "https://a.jpg"}]}`, []string{"https://a.jpg"}},
+		{"single image object", `{"image": {"@type": "ImageObject", "url": "https://a.jpg"}}`, []string{"https://a.jpg"}},
+		{"no image", `{"name": "A54"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonLDImages(mustJSON(t, tt.product)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("jsonLDImages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLargestImage(t *testing.T) {
+	tests := []struct {
+		src, srcset, want string
+	}{
+		{"https://s.jpg", "", "https://s.jpg"},
+		{"https://s.jpg", "https://100.jpg 100w, https://700.jpg 700w, https://300.jpg 300w", "https://700.jpg"},
+		{"https://s.jpg", "https://1x.jpg 1x, https://2x.jpg 2x", "https://2x.jpg"},
+	}
+	for _, tt := range tests {
+		if got := largestImage(tt.src, tt.srcset); got != tt.want {
+			t.Errorf("largestImage(%q, %q) = %q, want %q", tt.src, tt.srcset, got, tt.want)
+		}
+	}
+}
+
+func TestUniqueOriginals(t *testing.T) {
+	got := uniqueOriginals([]string{
+		"https://images.tokopedia.net/img/cache/700/VqbcmM/2023/1/1/a.jpg",
+		"https://images.tokopedia.net/img/cache/100-square/VqbcmM/2023/1/1/a.jpg",
+		"data:image/gif;base64,R0lGOD",
+		"",
+		"https://images.tokopedia.net/img/VqbcmM/2023/1/1/b.jpg",
+	})
+	want := []string{
+		"https://images.tokopedia.net/img/VqbcmM/2023/1/1/a.jpg",
+		"https://images.tokopedia.net/img/VqbcmM/2023/1/1/b.jpg",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueOriginals = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+package scrapper
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"github.com/playwright-community/playwright-go"
+)
+
+// firstPageTimeout is how long the first page of reviews or discussions may
+// take to request its GraphQL response after load, in milliseconds
+const firstPageTimeout = 10000
+
+// openPageExpecting opens url and waits for the GraphQL response matching
+// responseURL, which the page requests after load. A page that never
+// requests it is left to the reader to report as missing.
+func (s *ScrapperRepo) openPageExpecting(url string, responseURL *regexp.Regexp) error {
+	var openErr error
+	_, err := s.page.ExpectResponse(responseURL, func() error {
+		openErr = s.OpenPage(url)
+		return openErr
+	}, playwright.PageExpectResponseOptions{Timeout: playwright.Float(firstPageTimeout)})
+	if openErr != nil {
+		return openErr
+	}
+	if err != nil && !errors.Is(err, playwright.TimeoutError) {
+		return classifyNavigationError(url, err)
+	}
+	return nil
+}
+
+// nextPage clicks the pagination button the element matches and waits for
+// the GraphQL response matching responseURL. It returns ErrSelectorMissing on
+// the last page.
+func (s *ScrapperRepo) nextPage(element entity.FieldSelector, responseURL *regexp.Regexp) error {
+	locator, match := s.findElements(element)
+	if match.Err != nil {
+		return match.Err
+	}
+	next := locator.First()
+	enabled, err := next.IsEnabled()
+	if err != nil {
+		return classifyLocatorError(match.Matched, err)
+	}
+	if !enabled {
+		return newScrapeError("next page", match.Matched, ErrSelectorMissing, nil)
+	}
+
+	s.capture.reset()
+	_, err = s.page.ExpectResponse(responseURL, func() error {
+		return next.Click()
+	})
+	if err != nil {
+		return classifyNavigationError(s.page.URL(), err)
+	}
+
+	err = s.page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+		State: playwright.LoadStateNetworkidle,
+	})
+	if err != nil {
+		return classifyNavigationError(s.page.URL(), err)
+	}
+	return nil
+}
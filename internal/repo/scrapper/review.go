@@ -0,0 +1,94 @@
+package scrapper
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// ReviewOperation is the GraphQL query the review page loads each page of
+// reviews with. Only its payload carries the review IDs, so reviews are read
+// from the captured response rather than the DOM.
+const ReviewOperation = "productReviewList"
+
+// reviewResponseURL matches the GraphQL request of a review page, Tokopedia
+// puts the operation name in the path
+var reviewResponseURL = regexp.MustCompile(regexp.QuoteMeta(graphqlHost) + `/.*` + ReviewOperation)
+
+// OpenReviewPage opens the review page of a product and waits for its first
+// page of reviews to be captured
+func (s *ScrapperRepo) OpenReviewPage(url string) error {
+	return s.openPageExpecting(url, reviewResponseURL)
+}
+
+// GetProductReviews returns the reviews of the current review page and
+// whether there is a next page. A page without a captured review response
+// returns ErrSelectorMissing.
+func (s *ScrapperRepo) GetProductReviews() ([]entity.Review, bool, error) {
+	payload, ok := s.CapturedResponses()[ReviewOperation]
+	if !ok {
+		return nil, false, newScrapeError("read reviews", ReviewOperation, ErrSelectorMissing, nil)
+	}
+	reviewList, ok := resolve(payload, []string{"**", "productrevGetProductReviewList"}).(map[string]interface{})
+	if !ok {
+		return nil, false, newScrapeError("read reviews", ReviewOperation, ErrSelectorMissing, nil)
+	}
+
+	items, _ := reviewList["list"].([]interface{})
+	reviews := make([]entity.Review, 0, len(items))
+	for _, i := range items {
+		item, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if review, ok := parseReview(item); ok {
+			reviews = append(reviews, review)
+		}
+	}
+	hasNext, _ := reviewList["hasNext"].(bool)
+	return reviews, hasNext, nil
+}
+
+// NextReviewPage clicks the pagination button the element matches and waits
+// for the next page of reviews. It returns ErrSelectorMissing on the last page.
+func (s *ScrapperRepo) NextReviewPage(element entity.FieldSelector) error {
+	return s.nextPage(element, reviewResponseURL)
+}
+
+// parseReview reads one entry of the review list, e.g.
+//
+//	{feedbackID: "123", productRating: 5, message: "...", reviewCreateTime: "2 minggu lalu",
+//	 reviewCreateTimestamp: "1706745600", variantName: "Hitam", likeDislike: {totalLike: 3},
+//	 imageAttachments: [...], videoAttachments: [...]}
+func parseReview(item map[string]interface{}) (entity.Review, bool) {
+	id, ok := scalarString(item["feedbackID"])
+	if !ok || id == "" || id == "0" {
+		id, ok = scalarString(item["id"])
+	}
+	if !ok || id == "" {
+		return entity.Review{}, false
+	}
+
+	review := entity.Review{ExternalID: id}
+	if rating, ok := scalarString(item["productRating"]); ok {
+		review.Rating, _ = strconv.Atoi(rating)
+	}
+	review.Text, _ = scalarString(item["message"])
+	review.ReviewedAtText, _ = scalarString(item["reviewCreateTime"])
+	if timestamp, ok := scalarString(item["reviewCreateTimestamp"]); ok {
+		if seconds, err := strconv.ParseInt(timestamp, 10, 64); err == nil && seconds > 0 {
+			reviewedAt := time.Unix(seconds, 0).UTC()
+			review.ReviewedAt = &reviewedAt
+		}
+	}
+	review.Variant, _ = scalarString(item["variantName"])
+	if likes, ok := lookupPath(item, "likeDislike.totalLike"); ok {
+		review.HelpfulCount, _ = strconv.ParseInt(likes, 10, 64)
+	}
+	images, _ := item["imageAttachments"].([]interface{})
+	videos, _ := item["videoAttachments"].([]interface{})
+	review.HasMedia = len(images) > 0 || len(videos) > 0
+	return review, true
+}
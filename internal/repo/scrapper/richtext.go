@@ -0,0 +1,79 @@
+package scrapper
+
+import (
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Pseudo attributes of the selector file that read rich content instead of an attribute
+const (
+	// AttributeHTML reads the element as sanitized HTML
+	AttributeHTML = ":html"
+	// AttributeText reads the element as plain text keeping line breaks and list items
+	AttributeText = ":text"
+)
+
+// sanitizeHTMLScript rebuilds the element keeping only structural tags,
+// dropping every attribute, script, style and embedded media
+const sanitizeHTMLScript = `(root) => {
+	const allowed = new Set(['P', 'BR', 'UL', 'OL', 'LI', 'B', 'STRONG', 'I', 'EM', 'U', 'H1', 'H2', 'H3', 'H4', 'H5', 'H6', 'BLOCKQUOTE', 'PRE', 'TABLE', 'THEAD', 'TBODY', 'TR', 'TH', 'TD']);
+	const dropped = new Set(['SCRIPT', 'STYLE', 'IFRAME', 'OBJECT', 'EMBED', 'IMG', 'VIDEO', 'AUDIO', 'SVG', 'NOSCRIPT', 'TEMPLATE']);
+	const clean = (node, out) => {
+		for (const child of node.childNodes) {
+			if (child.nodeType === Node.TEXT_NODE) {
+				out.appendChild(document.createTextNode(child.textContent));
+			} else if (child.nodeType === Node.ELEMENT_NODE && !dropped.has(child.tagName)) {
+				if (allowed.has(child.tagName)) {
+					const copy = document.createElement(child.tagName);
+					clean(child, copy);
+					out.appendChild(copy);
+				} else {
+					clean(child, out);
+				}
+			}
+		}
+		return out;
+	};
+	return clean(root, document.createElement('div')).innerHTML;
+}`
+
+// structuredTextScript renders the element as text, one line per paragraph
+// or line break, list items prefixed with "- "
+const structuredTextScript = `(root) => {
+	const blocks = new Set(['P', 'DIV', 'UL', 'OL', 'H1', 'H2', 'H3', 'H4', 'H5', 'H6', 'BLOCKQUOTE', 'PRE', 'TABLE', 'TR', 'SECTION', 'ARTICLE']);
+	let text = '';
+	const walk = (node) => {
+		for (const child of node.childNodes) {
+			if (child.nodeType === Node.TEXT_NODE) {
+				text += child.textContent;
+			} else if (child.nodeType === Node.ELEMENT_NODE) {
+				if (child.tagName === 'SCRIPT' || child.tagName === 'STYLE') continue;
+				if (child.tagName === 'BR') { text += '\n'; continue; }
+				if (child.tagName === 'LI') text += '\n- ';
+				else if (blocks.has(child.tagName)) text += '\n';
+				walk(child);
+				if (blocks.has(child.tagName)) text += '\n';
+			}
+		}
+	};
+	walk(root);
+	return text;
+}`
+
+// readRichContent reads an element for the AttributeHTML and AttributeText pseudo attributes
+func readRichContent(locator playwright.Locator, attribute string) (string, error) {
+	script := sanitizeHTMLScript
+	if attribute == AttributeText {
+		script = structuredTextScript
+	}
+	value, err := locator.Evaluate(script, nil)
+	if err != nil {
+		return "", err
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected %s content %T", attribute, value)
+	}
+	return text, nil
+}
@@ -2,24 +2,37 @@ package scrapper
 
 import (
 	"context"
-	"errors"
+	"strings"
+	"sync"
 
 	"github.com/indragunawan95/topedcrawler/internal/entity"
 	"github.com/playwright-community/playwright-go"
 )
 
+// primaryWaitTimeout is how long the primary DOM alternative of a field may
+// take to render after load, in milliseconds. Fallbacks are not waited for.
+const primaryWaitTimeout = 1000
+
 type UrlRepoItf interface {
 	GetUrls(ctx context.Context) ([]entity.Url, error)
 }
 
+// ScrapperRepo drives one tab at a time, concurrent workers need one
+// ScrapperRepo each
 type ScrapperRepo struct {
 	browser playwright.Browser
 	page    playwright.Page
+	// GraphQL responses captured while the current page loads
+	capture *pageCapture
+
+	operationsMu sync.Mutex
+	operations   map[string]bool
 }
 
 func New(browser playwright.Browser) *ScrapperRepo {
 	return &ScrapperRepo{
-		browser: browser,
+		browser:    browser,
+		operations: map[string]bool{LayoutOperation: true, ReviewOperation: true, DiscussionOperation: true},
 	}
 }
 
@@ -28,26 +41,60 @@ func (s *ScrapperRepo) LaunchTab() error {
 	if err != nil {
 		return err
 	}
+	capture := newPageCapture()
+	page.OnResponse(func(response playwright.Response) {
+		s.onResponse(capture, response)
+	})
 	s.page = page
+	s.capture = capture
 	return nil
 }
 
 func (s *ScrapperRepo) OpenPage(url string) error {
-	_, err := s.page.Goto(url, playwright.PageGotoOptions{
+	s.capture.reset()
+	response, err := s.page.Goto(url, playwright.PageGotoOptions{
 		WaitUntil: playwright.WaitUntilStateLoad,
 	})
 	if err != nil {
-		return err
+		return classifyNavigationError(url, err)
+	}
+	// Goto returns a nil response for same-document navigations
+	if response != nil {
+		switch response.Status() {
+		case 404, 410:
+			return newScrapeError("open page", url, ErrNotFound, nil)
+		case 403, 429:
+			return newScrapeError("open page", url, ErrBlocked, nil)
+		}
+	}
+	if isCaptchaPage(s.page.URL()) {
+		return newScrapeError("open page", url, ErrBlocked, nil)
+	}
+	return nil
+}
+
+// LoadHTML renders a stored page, e.g. a fixture, in the current tab
+func (s *ScrapperRepo) LoadHTML(html string) error {
+	err := s.page.SetContent(html, playwright.PageSetContentOptions{
+		WaitUntil: playwright.WaitUntilStateLoad,
+	})
+	if err != nil {
+		return classifyNavigationError("inline html", err)
 	}
 	return nil
 }
 
+// Tokopedia redirects suspicious traffic to a captcha verification page
+func isCaptchaPage(url string) bool {
+	return strings.Contains(strings.ToLower(url), "captcha")
+}
+
 func (s *ScrapperRepo) ScrollPage() error {
 	// Scroll to the bottom of the page to trigger lazy loading.
 	// This JavaScript snippet will scroll to the bottom.
 	_, err := s.page.Evaluate("window.scrollTo(0, document.body.scrollHeight)")
 	if err != nil {
-		return err
+		return classifyNavigationError(s.page.URL(), err)
 	}
 
 	// Wait for the network to be idle after scrolling.
@@ -56,7 +103,7 @@ func (s *ScrapperRepo) ScrollPage() error {
 	}
 	err = s.page.WaitForLoadState(loadStateOptions)
 	if err != nil {
-		return err
+		return classifyNavigationError(s.page.URL(), err)
 	}
 	return nil
 }
@@ -65,108 +112,135 @@ func (s *ScrapperRepo) ClosePage() error {
 	return s.page.Close()
 }
 
-func (s *ScrapperRepo) GetProductTitle() (string, error) {
-	selector := "[data-testid='lblPDPDetailProductName']"
-	locator := s.page.Locator(selector)
-	if locator == nil {
-		return "", errors.New("locator not found")
-	}
-	title, err := locator.TextContent()
-	if err != nil {
-		return "", err
+// ExtractFields reads every field from the current page. The captured XHR,
+// JSON-LD and embedded state paths of a field are tried first, then its DOM alternatives
+// in order, and the first one yielding a non-empty value wins.
+// A field none of whose sources match gets ErrSelectorMissing.
+func (s *ScrapperRepo) ExtractFields(fields []entity.FieldSelector) []entity.FieldValue {
+	data := s.loadStructuredData()
+	data.responses = s.CapturedResponses()
+
+	root := s.page.Locator("html")
+	values := make([]entity.FieldValue, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, s.extractField(root, field, data))
 	}
-	return title, nil
+	return values
 }
 
-func (s *ScrapperRepo) GetProductDescription() (string, error) {
-	selector := "[data-testid='lblPDPDescriptionProduk']"
-	locator := s.page.Locator(selector)
-	if locator == nil {
-		return "", errors.New("locator not found")
-	}
-	description, err := locator.TextContent()
-	if err != nil {
-		return "", err
+// extractField reads a field, locating its DOM alternatives inside root
+func (s *ScrapperRepo) extractField(root playwright.Locator, field entity.FieldSelector, data structuredData) entity.FieldValue {
+	result := entity.FieldValue{Name: field.Name, MatchIndex: -1}
+
+	operation, xhrPath := splitXHRPath(field.XHR)
+	structured := []struct {
+		source string
+		node   interface{}
+		path   string
+	}{
+		{SourceXHR, data.responses[operation], xhrPath},
+		{SourceJSONLD, data.product, field.JSONLD},
+		{SourceState, data.state, field.State},
+	}
+	for _, candidate := range structured {
+		value, ok := lookupPath(candidate.node, candidate.path)
+		if !ok {
+			continue
+		}
+		value, err := applyTransforms(value, field.Transforms)
+		if err != nil || value == "" {
+			continue
+		}
+		result.Value = value
+		result.Source = candidate.source
+		return result
 	}
-	return description, nil
-}
 
-func (s *ScrapperRepo) GetProductStoreName() (string, error) {
-	selector := "a[data-testid='llbPDPFooterShopName'] h2"
-	locator := s.page.Locator(selector)
-	if locator == nil {
-		return "", errors.New("locator not found")
+	var lastErr error
+	for i, alternative := range field.Alternatives() {
+		value, err := s.readAlternative(root, alternative, field, i == 0)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		result.Value = value
+		result.Source = SourceDOM
+		result.Matched = alternative.Name
+		result.MatchIndex = i
+		return result
 	}
-	storeName, err := locator.TextContent()
-	if err != nil {
-		return "", err
+
+	result.Err = lastErr
+	if result.Err == nil {
+		result.Err = newScrapeError("read element", field.Name, ErrSelectorMissing, nil)
 	}
-	return storeName, nil
+	return result
 }
 
-func (s *ScrapperRepo) GetProductPrice() (string, error) {
-	selector := "[data-testid='lblPDPDetailProductPrice']"
-	locator := s.page.Locator(selector)
-	if locator == nil {
-		return "", errors.New("locator not found")
+func (s *ScrapperRepo) readAlternative(root playwright.Locator, alternative entity.SelectorAlternative, field entity.FieldSelector, primary bool) (string, error) {
+	locator := locate(root, alternative)
+
+	// The primary alternative may render after load. A timeout here only
+	// means the element is absent, the Count below reports it as missing.
+	if primary {
+		waitAttached(locator)
 	}
-	price, err := locator.TextContent()
+
+	// Count does not wait, so a dead fallback does not cost a full timeout
+	count, err := locator.Count()
 	if err != nil {
-		return "", err
+		return "", classifyLocatorError(alternative.Name, err)
+	}
+	if count == 0 {
+		return "", newScrapeError("read element", alternative.Name, ErrSelectorMissing, nil)
 	}
-	return price, nil
-}
 
-func (s *ScrapperRepo) GetProductRating() (string, error) {
-	selector := "[data-testid='lblPDPDetailProductRatingNumber']"
-	locator := s.page.Locator(selector)
-	// Attempt to get the text content of the locator
-	rating, err := locator.TextContent()
-	// If there is an error, return "0" as the default rating
+	var value string
+	switch field.Attribute {
+	case "":
+		value, err = locator.TextContent()
+	case AttributeHTML, AttributeText:
+		value, err = readRichContent(locator, field.Attribute)
+	default:
+		value, err = locator.GetAttribute(field.Attribute)
+	}
 	if err != nil {
-		// You might want to log the error or handle different types of errors appropriately
-		return "0", nil
+		return "", classifyLocatorError(alternative.Name, err)
 	}
-	// No error, return the rating
-	return rating, nil
-}
 
-func (s *ScrapperRepo) GetProductImageLink() (string, error) {
-	// Using data-testid to select the image element
-	selector := "[data-testid='PDPMainImage']"
-	locator := s.page.Locator(selector)
-	if locator == nil {
-		return "", errors.New("locator not found")
-	}
-	// Get the "src" attribute of the image element
-	imageLink, err := locator.GetAttribute("src")
+	value, err = applyTransforms(value, field.Transforms)
 	if err != nil {
 		return "", err
 	}
-	return imageLink, nil
+	if value == "" {
+		return "", newScrapeError("read element", alternative.Name, ErrSelectorMissing, nil)
+	}
+	return value, nil
 }
 
-func (s *ScrapperRepo) GetAllProductLinks() ([]string, error) {
-	// Selector for all elements with the specific data-testid
-	selector := "a[data-testid='lnkProductContainer']"
-	// Create a locator for all elements matching the selector
-	locator := s.page.Locator(selector)
+// locate resolves an alternative inside root, the whole page or a listing card.
+// A css ":scope" selector matches root itself.
+// waitAttached waits up to primaryWaitTimeout for the first element of locator
+func waitAttached(locator playwright.Locator) {
+	_ = locator.First().WaitFor(playwright.LocatorWaitForOptions{
+		State:   playwright.WaitForSelectorStateAttached,
+		Timeout: playwright.Float(primaryWaitTimeout),
+	})
+}
 
-	// Count the number of elements matched by the locator
-	count, err := locator.Count()
-	if err != nil {
-		return nil, err
-	}
+func locate(root playwright.Locator, alternative entity.SelectorAlternative) playwright.Locator {
+	return locateAll(root, alternative).First()
+}
 
-	var links []string
-	for i := 0; i < count; i++ {
-		// Get the "href" attribute of each element by using the nth locator
-		href, err := locator.Nth(i).GetAttribute("href")
-		if err != nil {
-			// Decide how to handle the error; skip to the next iteration or return
-			continue
-		}
-		links = append(links, href)
-	}
-	return links, nil
+// locateAll locates every element of an alternative inside root
+func locateAll(root playwright.Locator, alternative entity.SelectorAlternative) playwright.Locator {
+	switch alternative.Strategy() {
+	case "xpath":
+		return root.Locator("xpath=" + alternative.XPath)
+	case "text":
+		// The value sits in the element right after its label, e.g. <p>Kondisi</p><p>Baru</p>
+		return root.GetByText(alternative.Text, playwright.LocatorGetByTextOptions{Exact: playwright.Bool(true)}).
+			Locator("xpath=following-sibling::*[1]")
+	}
+	return root.Locator(alternative.CSS)
 }
@@ -0,0 +1,100 @@
+package scrapper
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadSelectors reads and validates the selector file
+func LoadSelectors(path string) (*entity.SelectorSet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selector file: %w", err)
+	}
+
+	set := &entity.SelectorSet{}
+	if err := yaml.Unmarshal(b, set); err != nil {
+		return nil, fmt.Errorf("failed to parse selector file: %w", err)
+	}
+
+	if err := validateSelectors(set); err != nil {
+		return nil, fmt.Errorf("invalid selector file %s: %w", path, err)
+	}
+	return set, nil
+}
+
+func validateSelectors(set *entity.SelectorSet) error {
+	if set.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	for siteName, site := range set.Sites {
+		for pageName, page := range site.Pages {
+			seen := make(map[string]bool)
+			for _, field := range page.Fields {
+				if field.Name == "" {
+					return fmt.Errorf("%s/%s: field without name", siteName, pageName)
+				}
+				if seen[field.Name] {
+					return fmt.Errorf("%s/%s: duplicate field %q", siteName, pageName, field.Name)
+				}
+				seen[field.Name] = true
+				if len(field.Alternatives()) == 0 && field.XHR == "" && field.JSONLD == "" && field.State == "" {
+					return fmt.Errorf("%s/%s: field %q has no selector", siteName, pageName, field.Name)
+				}
+				if page.Container != "" && (field.XHR != "" || field.JSONLD != "" || field.State != "") {
+					return fmt.Errorf("%s/%s: field %q is read per container, from the DOM only", siteName, pageName, field.Name)
+				}
+				if field.XHR != "" && !strings.Contains(field.XHR, ":") {
+					return fmt.Errorf("%s/%s: field %q xhr must be OperationName:path", siteName, pageName, field.Name)
+				}
+				for i, alternative := range field.Selectors {
+					if countStrategies(alternative) != 1 {
+						return fmt.Errorf("%s/%s: field %q selector %d must set exactly one of css, xpath or text", siteName, pageName, field.Name, i)
+					}
+				}
+				for _, name := range field.Transforms {
+					if _, ok := transforms[name]; !ok {
+						return fmt.Errorf("%s/%s: field %q uses unknown transform %q", siteName, pageName, field.Name, name)
+					}
+				}
+			}
+
+			seen = make(map[string]bool)
+			for _, element := range page.Elements {
+				if element.Name == "" {
+					return fmt.Errorf("%s/%s: element without name", siteName, pageName)
+				}
+				if seen[element.Name] {
+					return fmt.Errorf("%s/%s: duplicate element %q", siteName, pageName, element.Name)
+				}
+				seen[element.Name] = true
+				if len(element.Alternatives()) == 0 {
+					return fmt.Errorf("%s/%s: element %q has no selector", siteName, pageName, element.Name)
+				}
+				if element.XHR != "" || element.JSONLD != "" || element.State != "" {
+					return fmt.Errorf("%s/%s: element %q is read from the DOM only", siteName, pageName, element.Name)
+				}
+				for i, alternative := range element.Selectors {
+					if countStrategies(alternative) != 1 {
+						return fmt.Errorf("%s/%s: element %q selector %d must set exactly one of css, xpath or text", siteName, pageName, element.Name, i)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func countStrategies(alternative entity.SelectorAlternative) int {
+	count := 0
+	for _, value := range []string{alternative.CSS, alternative.XPath, alternative.Text} {
+		if value != "" {
+			count++
+		}
+	}
+	return count
+}
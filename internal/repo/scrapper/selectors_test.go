@@ -0,0 +1,60 @@
+package scrapper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestLoadSelectorsFile(t *testing.T) {
+	set, err := LoadSelectors("../../../files/config/selectors.yaml")
+	if err != nil {
+		t.Fatalf("LoadSelectors: %v", err)
+	}
+	pdp, err := set.Page("tokopedia", "pdp")
+	if err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+	if len(pdp.Element("specs").Alternatives()) == 0 {
+		t.Error("pdp has no specs element")
+	}
+	if len(pdp.Element("unknown").Alternatives()) != 0 {
+		t.Error("unknown element has alternatives")
+	}
+}
+
+func TestValidateSelectorsElements(t *testing.T) {
+	tests := []struct {
+		name    string
+		element entity.FieldSelector
+		wantErr string
+	}{
+		{"valid", entity.FieldSelector{Name: "specs", Selector: "li"}, ""},
+		{"no name", entity.FieldSelector{Selector: "li"}, "element without name"},
+		{"no selector", entity.FieldSelector{Name: "specs"}, "has no selector"},
+		{"structured path", entity.FieldSelector{Name: "specs", Selector: "li", JSONLD: "additionalProperty"}, "DOM only"},
+		{
+			"two strategies",
+			entity.FieldSelector{Name: "specs", Selectors: []entity.SelectorAlternative{{CSS: "li", XPath: "//li"}}},
+			"exactly one",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &entity.SelectorSet{
+				Version: "test",
+				Sites: map[string]entity.SiteSelectors{"tokopedia": {Pages: map[string]entity.PageSelectors{
+					"pdp": {Elements: []entity.FieldSelector{tt.element}},
+				}}},
+			}
+			err := validateSelectors(set)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("validateSelectors = %v, want no error", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("validateSelectors = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
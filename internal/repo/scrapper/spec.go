@@ -0,0 +1,39 @@
+package scrapper
+
+import (
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// GetProductSpecs returns the raw label and value of every entry of the detail
+// list, the element one "Label: value" entry per match. Keys and values are
+// normalised by the caller.
+func (s *ScrapperRepo) GetProductSpecs(element entity.FieldSelector) ([]entity.ProductSpec, error) {
+	locator, match := s.findElements(element)
+	if match.Err != nil {
+		return nil, match.Err
+	}
+	texts, err := locator.AllInnerTexts()
+	if err != nil {
+		return nil, classifyLocatorError(match.Matched, err)
+	}
+
+	var specs []entity.ProductSpec
+	for _, text := range texts {
+		label, value, ok := strings.Cut(text, ":")
+		if !ok {
+			continue
+		}
+		label = strings.Join(strings.Fields(label), " ")
+		value = strings.Join(strings.Fields(value), " ")
+		if label == "" || value == "" {
+			continue
+		}
+		specs = append(specs, entity.ProductSpec{Label: label, RawValue: value, Source: entity.SpecSourceDetail})
+	}
+	if len(specs) == 0 {
+		return nil, newScrapeError("read specs", match.Matched, ErrSelectorMissing, nil)
+	}
+	return specs, nil
+}
@@ -0,0 +1,183 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sources a field value can be read from, in the order they are tried
+const (
+	SourceJSONLD = "jsonld"
+	SourceState  = "state"
+	SourceDOM    = "dom"
+)
+
+// structuredDataScript collects the JSON-LD blobs and the embedded Apollo cache of the page
+const structuredDataScript = `() => {
+	const ld = Array.from(document.querySelectorAll('script[type="application/ld+json"]')).map(s => s.textContent);
+	const state = window.__cache || window.__APOLLO_STATE__ || null;
+	return { ld: ld, state: state ? JSON.stringify(state) : "" };
+}`
+
+// structuredData holds the machine readable data of a product page
+type structuredData struct {
+	product    interface{}            // the schema.org Product object from JSON-LD
+	breadcrumb interface{}            // the schema.org BreadcrumbList object from JSON-LD
+	state      interface{}            // the embedded Apollo/GraphQL cache
+	responses  map[string]interface{} // captured GraphQL responses by operation name
+}
+
+// loadStructuredData reads the structured blobs of the current page. Broken or
+// absent blobs are not an error, the DOM is used instead.
+func (s *ScrapperRepo) loadStructuredData() structuredData {
+	var data structuredData
+
+	raw, err := s.page.Evaluate(structuredDataScript)
+	if err != nil {
+		return data
+	}
+	blobs, ok := raw.(map[string]interface{})
+	if !ok {
+		return data
+	}
+
+	if ld, ok := blobs["ld"].([]interface{}); ok {
+		for _, blob := range ld {
+			text, _ := blob.(string)
+			var parsed interface{}
+			if json.Unmarshal([]byte(text), &parsed) != nil {
+				continue
+			}
+			if product := findJSONLDType(parsed, "Product"); product != nil && data.product == nil {
+				data.product = product
+			}
+			if breadcrumb := findJSONLDType(parsed, "BreadcrumbList"); breadcrumb != nil && data.breadcrumb == nil {
+				data.breadcrumb = breadcrumb
+			}
+		}
+	}
+
+	if state, ok := blobs["state"].(string); ok && state != "" {
+		var parsed interface{}
+		if json.Unmarshal([]byte(state), &parsed) == nil {
+			data.state = parsed
+		}
+	}
+	return data
+}
+
+// findJSONLDType returns the object with the given "@type", e.g. "Product",
+// looking into arrays and @graph containers
+func findJSONLDType(node interface{}, schemaType string) map[string]interface{} {
+	switch v := node.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if found := findJSONLDType(item, schemaType); found != nil {
+				return found
+			}
+		}
+	case map[string]interface{}:
+		if v["@type"] == schemaType {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJSONLDType(graph, schemaType)
+		}
+	}
+	return nil
+}
+
+// splitXHRPath splits "Operation:path" into the operation name and the path
+func splitXHRPath(xhr string) (string, string) {
+	operation, path, ok := strings.Cut(xhr, ":")
+	if !ok {
+		return "", ""
+	}
+	return operation, path
+}
+
+// lookupPath resolves a dot separated path such as "offers.price" or
+// "image.0". A "**" segment searches every depth for the rest of the path.
+// Arrays resolve to their first element when the path ends on them.
+func lookupPath(node interface{}, path string) (string, bool) {
+	if node == nil || path == "" {
+		return "", false
+	}
+	found := resolve(node, strings.Split(path, "."))
+	if list, ok := found.([]interface{}); ok {
+		if len(list) == 0 {
+			return "", false
+		}
+		found = list[0]
+	}
+	return scalarString(found)
+}
+
+func resolve(node interface{}, segments []string) interface{} {
+	if len(segments) == 0 {
+		return node
+	}
+	segment, rest := segments[0], segments[1:]
+
+	if segment == "**" {
+		if found := resolve(node, rest); found != nil {
+			return found
+		}
+		for _, child := range children(node) {
+			if found := resolve(child, segments); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+
+	switch v := node.(type) {
+	case map[string]interface{}:
+		child, ok := v[segment]
+		if !ok {
+			return nil
+		}
+		return resolve(child, rest)
+	case []interface{}:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil
+		}
+		return resolve(v[index], rest)
+	}
+	return nil
+}
+
+// children returns the child nodes in a stable order so "**" lookups are deterministic
+func children(node interface{}) []interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		out := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			out = append(out, v[key])
+		}
+		return out
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
@@ -0,0 +1,135 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mustJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var node interface{}
+	if err := json.Unmarshal([]byte(raw), &node); err != nil {
+		t.Fatalf("invalid fixture %s: %v", raw, err)
+	}
+	return node
+}
+
+func TestLookupPath(t *testing.T) {
+	product := `{
+		"name": "Samsung Galaxy A54",
+		"offers": {"price": 5499000, "availability": "http://schema.org/InStock"},
+		"image": ["https://images.tokopedia.net/a.jpg", "https://images.tokopedia.net/b.jpg"],
+		"aggregateRating": {"ratingValue": "4.9"},
+		"isNew": true
+	}`
+	state := `{
+		"ROOT_QUERY": {"pdpGetLayout": {"__ref": "layout"}},
+		"Product:1": {"basicInfo": {"shopID": "42"}},
+		"Product:2": {"basicInfo": {"shopID": "43"}}
+	}`
+
+	tests := []struct {
+		name   string
+		node   string
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"nested number", product, "offers.price", "5499000", true},
+		{"string", product, "offers.availability", "http://schema.org/InStock", true},
+		{"array index", product, "image.1", "https://images.tokopedia.net/b.jpg", true},
+		{"array resolves to first element", product, "image", "https://images.tokopedia.net/a.jpg", true},
+		{"bool", product, "isNew", "true", true},
+		{"missing key", product, "offers.priceCurrency", "", false},
+		{"index out of range", product, "image.5", "", false},
+		{"object is not a scalar", product, "offers", "", false},
+		{"empty path", product, "", "", false},
+		{"any depth takes the first key in order", state, "**.basicInfo.shopID", "42", true},
+		{"any depth without match", state, "**.basicInfo.sku", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := lookupPath(mustJSON(t, tt.node), tt.path)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("lookupPath(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLookupPathNilNode(t *testing.T) {
+	if got, ok := lookupPath(nil, "offers.price"); ok || got != "" {
+		t.Errorf("lookupPath(nil) = %q, %v, want no value", got, ok)
+	}
+}
+
+func TestFindJSONLDType(t *testing.T) {
+	tests := []struct {
+		name string
+		blob string
+		want string // name of the found object, empty when none
+	}{
+		{"top level", `{"@type": "Product", "name": "A54"}`, "A54"},
+		{"array", `[{"@type": "BreadcrumbList"}, {"@type": "Product", "name": "A54"}]`, "A54"},
+		{"graph", `{"@graph": [{"@type": "Organization"}, {"@type": "Product", "name": "A54"}]}`, "A54"},
+		{"absent", `{"@type": "Organization", "name": "Tokopedia"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := findJSONLDType(mustJSON(t, tt.blob), "Product")
+			got, _ := found["name"].(string)
+			if got != tt.want {
+				t.Errorf("findJSONLDType = %v, want name %q", found, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitXHRPath(t *testing.T) {
+	operation, path := splitXHRPath("PDPGetLayoutQuery:data.pdpGetLayout.basicInfo.shopID")
+	if operation != "PDPGetLayoutQuery" || path != "data.pdpGetLayout.basicInfo.shopID" {
+		t.Errorf("splitXHRPath = %q, %q", operation, path)
+	}
+	if operation, path := splitXHRPath("no-operation"); operation != "" || path != "" {
+		t.Errorf("splitXHRPath without operation = %q, %q, want empty", operation, path)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	payload := mustJSON(t, `{
+		"data": {"pdpGetLayout": {"components": [
+			{"name": "product_content", "data": [{"price": {"value": 5499000}}]},
+			{"name": "variant_options", "data": [{"variants": []}]}
+		]}}
+	}`)
+
+	tests := []struct {
+		name     string
+		segments []string
+		want     string // JSON of the found node, empty when nil
+	}{
+		{"no segments returns the node", nil, "object"},
+		{"array index", []string{"data", "pdpGetLayout", "components", "1", "name"}, `"variant_options"`},
+		{"any depth", []string{"**", "price", "value"}, "5499000"},
+		{"any depth in the middle", []string{"data", "**", "variants"}, "[]"},
+		{"missing", []string{"**", "wholesale"}, ""},
+		{"index on an object", []string{"data", "0"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := resolve(payload, tt.segments)
+			var got string
+			switch v := found.(type) {
+			case nil:
+			case map[string]interface{}:
+				got = "object"
+			default:
+				b, _ := json.Marshal(v)
+				got = string(b)
+			}
+			if got != tt.want {
+				t.Errorf("resolve(%q) = %s, want %s", tt.segments, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package scrapper
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// transforms are the value transforms available to the selector file
+var transforms = map[string]func(string) string{
+	"trim": strings.TrimSpace,
+	"collapse_space": func(value string) string {
+		return strings.Join(strings.Fields(value), " ")
+	},
+	"lower": strings.ToLower,
+	// trim_lines trims every line and keeps at most one blank line between paragraphs
+	"trim_lines": func(value string) string {
+		var lines []string
+		blank := false
+		for _, line := range strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n") {
+			line = strings.Join(strings.Fields(line), " ")
+			if line == "" {
+				blank = len(lines) > 0
+				continue
+			}
+			if blank {
+				lines = append(lines, "")
+				blank = false
+			}
+			lines = append(lines, line)
+		}
+		return strings.Join(lines, "\n")
+	},
+	// last_segment turns schema.org values like "http://schema.org/InStock" into "InStock"
+	"last_segment": func(value string) string {
+		value = strings.TrimRight(strings.TrimSpace(value), "/")
+		return value[strings.LastIndex(value, "/")+1:]
+	},
+	"digits": func(value string) string {
+		// Remove all non-digit characters
+		return strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, value)
+	},
+}
+
+func applyTransforms(value string, names []string) (string, error) {
+	for _, name := range names {
+		transform, ok := transforms[name]
+		if !ok {
+			return "", fmt.Errorf("unknown transform %q", name)
+		}
+		value = transform(value)
+	}
+	return value, nil
+}
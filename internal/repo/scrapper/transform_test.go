@@ -0,0 +1,28 @@
+package scrapper
+
+import "testing"
+
+func TestApplyTransforms(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		transforms []string
+		want       string
+		wantErr    bool
+	}{
+		{"trim_lines", "  Spesifikasi:  \r\n RAM:   8GB \n\n\n\n- Baterai 5000 mAh\n\n", []string{"trim_lines"}, "Spesifikasi:\nRAM: 8GB\n\n- Baterai 5000 mAh", false},
+		{"trim_lines drops leading blank lines", "\n\n  Garansi resmi", []string{"trim_lines"}, "Garansi resmi", false},
+		{"collapse_space", " Samsung   Galaxy\tA54 ", []string{"collapse_space"}, "Samsung Galaxy A54", false},
+		{"last_segment", "http://schema.org/InStock/", []string{"last_segment"}, "InStock", false},
+		{"digits then trim", " Rp5.499.000 ", []string{"digits", "trim"}, "5499000", false},
+		{"unknown", "x", []string{"upper"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyTransforms(tt.value, tt.transforms)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("applyTransforms(%q, %v) = %q, %v, want %q, wantErr %v", tt.value, tt.transforms, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
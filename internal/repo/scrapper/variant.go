@@ -0,0 +1,106 @@
+package scrapper
+
+import (
+	"strconv"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// LayoutOperation is the GraphQL query the product page loads its layout,
+// including the variant matrix, with. It is always captured.
+const LayoutOperation = "PDPGetLayoutQuery"
+
+// GetProductVariants reads the variant matrix of the current page from the
+// captured layout response, or from the embedded Apollo cache, and returns
+// the source used. A product without variants returns ErrSelectorMissing.
+func (s *ScrapperRepo) GetProductVariants() ([]entity.ProductVariant, string, error) {
+	sources := []struct {
+		name string
+		node interface{}
+	}{
+		{SourceXHR, s.CapturedResponses()[LayoutOperation]},
+		{SourceState, s.loadStructuredData().state},
+	}
+	for _, source := range sources {
+		matrix := findVariantMatrix(source.node)
+		if matrix == nil {
+			continue
+		}
+		if variants := parseVariantMatrix(matrix); len(variants) > 0 {
+			return variants, source.name, nil
+		}
+	}
+	return nil, "", newScrapeError("read variants", LayoutOperation, ErrSelectorMissing, nil)
+}
+
+// findVariantMatrix returns the first object holding both the option
+// definitions ("variants") and the option combinations ("children")
+func findVariantMatrix(node interface{}) map[string]interface{} {
+	if object, ok := node.(map[string]interface{}); ok {
+		_, hasVariants := object["variants"].([]interface{})
+		_, hasChildren := object["children"].([]interface{})
+		if hasVariants && hasChildren {
+			return object
+		}
+	}
+	for _, child := range children(node) {
+		if matrix := findVariantMatrix(child); matrix != nil {
+			return matrix
+		}
+	}
+	return nil
+}
+
+// parseVariantMatrix turns
+//
+//	variants: [{name: "Warna", option: [{productVariantOptionID: 1, value: "Hitam"}]}]
+//	children: [{optionID: [1], price: 1299000, stock: {stock: 5}, sku: "..."}]
+//
+// into one ProductVariant per child
+func parseVariantMatrix(matrix map[string]interface{}) []entity.ProductVariant {
+	options := make(map[string]entity.VariantOption)
+	for _, v := range matrix["variants"].([]interface{}) {
+		variant, _ := v.(map[string]interface{})
+		name, _ := scalarString(variant["name"])
+		list, _ := variant["option"].([]interface{})
+		for _, o := range list {
+			option, _ := o.(map[string]interface{})
+			id, ok := scalarString(option["productVariantOptionID"])
+			if !ok {
+				continue
+			}
+			value, _ := scalarString(option["value"])
+			options[id] = entity.VariantOption{Name: name, Value: value}
+		}
+	}
+
+	var variants []entity.ProductVariant
+	for _, c := range matrix["children"].([]interface{}) {
+		child, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		variant := entity.ProductVariant{}
+		optionIDs, _ := child["optionID"].([]interface{})
+		for _, id := range optionIDs {
+			key, _ := scalarString(id)
+			if option, ok := options[key]; ok {
+				variant.Options = append(variant.Options, option)
+			}
+		}
+		variant.SKU, _ = scalarString(child["sku"])
+
+		// Prices come as numbers or as strings, formatted or not
+		if text, ok := scalarString(child["price"]); ok {
+			if price, err := entity.ParseIDR(text, true); err == nil {
+				variant.Price = price
+			}
+		}
+		stock, _ := lookupPath(child, "stock.stock")
+		variant.Stock, _ = strconv.ParseInt(stock, 10, 64)
+
+		variants = append(variants, variant)
+	}
+	return variants
+}
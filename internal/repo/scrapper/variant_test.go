@@ -0,0 +1,72 @@
+package scrapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestParseVariantMatrix(t *testing.T) {
+	layout := mustJSON(t, `{"data": {"pdpGetLayout": {"components": [{"data": [{
+		"variants": [
+			{"name": "Warna", "option": [{"productVariantOptionID": 1, "value": "Hitam"}, {"productVariantOptionID": 2, "value": "Putih"}]},
+			{"name": "Memori", "option": [{"productVariantOptionID": "10", "value": "128GB"}]}
+		],
+		"children": [
+			{"optionID": [1, 10], "price": 1299000, "sku": "A-1", "stock": {"stock": "5"}},
+			{"optionID": [2, 10], "price": 1349000.5, "stock": {"stock": 0}},
+			"not a child"
+		]
+	}]}]}}}`)
+
+	matrix := findVariantMatrix(layout)
+	if matrix == nil {
+		t.Fatal("findVariantMatrix found no matrix")
+	}
+	got := parseVariantMatrix(matrix)
+	want := []entity.ProductVariant{
+		{
+			Options: []entity.VariantOption{{Name: "Warna", Value: "Hitam"}, {Name: "Memori", Value: "128GB"}},
+			SKU:     "A-1",
+			Price:   entity.Money{Amount: 129900000, Currency: entity.CurrencyIDR},
+			Stock:   5,
+		},
+		{
+			Options: []entity.VariantOption{{Name: "Warna", Value: "Putih"}, {Name: "Memori", Value: "128GB"}},
+			Price:   entity.Money{Amount: 134900050, Currency: entity.CurrencyIDR},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseVariantMatrix =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseVariantMatrixPrices(t *testing.T) {
+	tests := []struct {
+		price string // json value of the child price
+		want  int64  // minor units
+	}{
+		{`1250000`, 125000000},
+		{`"1250000"`, 125000000},
+		{`"Rp1.250.000"`, 125000000},
+		{`"Rp 1.250.000,50"`, 125000050},
+		{`"gratis"`, 0},
+		{`null`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.price, func(t *testing.T) {
+			matrix, _ := mustJSON(t, `{"variants": [], "children": [{"optionID": [], "price": `+tt.price+`}]}`).(map[string]interface{})
+			got := parseVariantMatrix(matrix)
+			if len(got) != 1 || got[0].Price.Amount != tt.want {
+				t.Errorf("parseVariantMatrix = %+v, want price %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindVariantMatrixWithoutVariants(t *testing.T) {
+	if matrix := findVariantMatrix(mustJSON(t, `{"data": {"variants": [], "other": {}}}`)); matrix != nil {
+		t.Errorf("findVariantMatrix = %v, want nil without children", matrix)
+	}
+}
@@ -0,0 +1,66 @@
+package scrapper
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// GetProductWholesale reads the wholesale (harga grosir) price tiers of the
+// current page from the captured layout response, or from the embedded Apollo
+// cache, and returns the source used. A product without wholesale prices
+// returns ErrSelectorMissing.
+func (s *ScrapperRepo) GetProductWholesale() ([]entity.WholesaleTier, string, error) {
+	sources := []struct {
+		name string
+		node interface{}
+	}{
+		{SourceXHR, s.CapturedResponses()[LayoutOperation]},
+		{SourceState, s.loadStructuredData().state},
+	}
+	for _, source := range sources {
+		list, ok := resolve(source.node, []string{"**", "wholesale"}).([]interface{})
+		if !ok {
+			continue
+		}
+		if tiers := parseWholesale(list); len(tiers) > 0 {
+			return tiers, source.name, nil
+		}
+	}
+	return nil, "", newScrapeError("read wholesale", LayoutOperation, ErrSelectorMissing, nil)
+}
+
+// parseWholesale turns [{minQty: 5, price: {value: 1250000}}] into tiers
+// ordered by quantity, each ending where the next one starts
+func parseWholesale(list []interface{}) []entity.WholesaleTier {
+	var tiers []entity.WholesaleTier
+	for _, item := range list {
+		tier, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		minQty, _ := scalarString(tier["minQty"])
+		qty, err := strconv.ParseInt(minQty, 10, 64)
+		if err != nil || qty <= 0 {
+			continue
+		}
+		price, ok := tier["price"].(float64)
+		if !ok {
+			price, ok = resolve(tier, []string{"price", "value"}).(float64)
+		}
+		if !ok || price <= 0 {
+			continue
+		}
+		tiers = append(tiers, entity.WholesaleTier{
+			MinQty: qty,
+			Price:  entity.Money{Amount: int64(price*100 + 0.5), Currency: entity.CurrencyIDR},
+		})
+	}
+
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinQty < tiers[j].MinQty })
+	for i := 0; i+1 < len(tiers); i++ {
+		tiers[i].MaxQty = tiers[i+1].MinQty - 1
+	}
+	return tiers
+}
@@ -0,0 +1,46 @@
+package scrapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestParseWholesale(t *testing.T) {
+	idr := func(rupiah int64) entity.Money {
+		return entity.Money{Amount: rupiah * 100, Currency: entity.CurrencyIDR}
+	}
+	tests := []struct {
+		name string
+		list string
+		want []entity.WholesaleTier
+	}{
+		{
+			name: "ordered by quantity, each tier ending where the next starts",
+			list: `[{"minQty": 10, "price": {"value": 1200000}}, {"minQty": 5, "price": 1250000}]`,
+			want: []entity.WholesaleTier{
+				{MinQty: 5, MaxQty: 9, Price: idr(1250000)},
+				{MinQty: 10, Price: idr(1200000)},
+			},
+		},
+		{
+			name: "string quantities",
+			list: `[{"minQty": "3", "price": {"value": 99000}}]`,
+			want: []entity.WholesaleTier{{MinQty: 3, Price: idr(99000)}},
+		},
+		{
+			name: "tiers without quantity or price are skipped",
+			list: `[{"minQty": 0, "price": 1000}, {"minQty": 2}, {"minQty": 4, "price": 0}, "tier"]`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, _ := mustJSON(t, tt.list).([]interface{})
+			if got := parseWholesale(list); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWholesale = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
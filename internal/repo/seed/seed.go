@@ -0,0 +1,51 @@
+package seed
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadSeeds reads and validates the seed file
+func LoadSeeds(path string) (*entity.SeedSet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+
+	set := &entity.SeedSet{}
+	if err := yaml.Unmarshal(b, set); err != nil {
+		return nil, fmt.Errorf("failed to parse seed file: %w", err)
+	}
+
+	if err := validateSeeds(set); err != nil {
+		return nil, fmt.Errorf("invalid seed file %s: %w", path, err)
+	}
+	return set, nil
+}
+
+func validateSeeds(set *entity.SeedSet) error {
+	if len(set.Seeds) == 0 {
+		return fmt.Errorf("no seeds")
+	}
+	seen := make(map[string]bool)
+	for i, seed := range set.Seeds {
+		if seed.Name == "" {
+			return fmt.Errorf("seed %d without name", i)
+		}
+		if seen[seed.Name] {
+			return fmt.Errorf("duplicate seed %q", seed.Name)
+		}
+		seen[seed.Name] = true
+		if strings.Count(seed.Url, "%d") != 1 {
+			return fmt.Errorf("seed %q url must have one %%d page placeholder", seed.Name)
+		}
+		if seed.DiscussionPages < 0 {
+			return fmt.Errorf("seed %q discussion_pages must not be negative", seed.Name)
+		}
+	}
+	return nil
+}
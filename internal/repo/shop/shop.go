@@ -0,0 +1,61 @@
+package shop
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShopRepo struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *ShopRepo {
+	return &ShopRepo{
+		db: db,
+	}
+}
+
+// GetShopByDomain returns the stored shop, found is false when it is not stored yet
+func (sr ShopRepo) GetShopByDomain(ctx context.Context, domain string) (entity.Shop, bool, error) {
+	var model entity.ShopModel
+
+	err := sr.db.WithContext(ctx).Where("domain = ?", domain).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.Shop{}, false, nil
+	}
+	if err != nil {
+		return entity.Shop{}, false, err
+	}
+	return model.ToEntity(), true, nil
+}
+
+// UpsertShop inserts the shop or updates the stored one with the same domain
+func (sr ShopRepo) UpsertShop(ctx context.Context, input entity.Shop) (entity.Shop, error) {
+	input.ID = uuid.New().String()
+	model := input.ToModel()
+
+	err := sr.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "domain"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"external_id", "name", "city", "badge", "rating", "response_time", "joined_at", "scraped_at", "updated_at",
+		}),
+	}).Create(&model).Error
+	if err != nil {
+		return entity.Shop{}, err
+	}
+
+	// On conflict the generated ID is not the stored one, read it back
+	output, found, err := sr.GetShopByDomain(ctx, input.Domain)
+	if err != nil {
+		return entity.Shop{}, err
+	}
+	if !found {
+		return entity.Shop{}, errors.New("shop not found after upsert")
+	}
+	return output, nil
+}
@@ -0,0 +1,135 @@
+package ranktracker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+type RankRepoItf interface {
+	GetSeeds(ctx context.Context) ([]string, error)
+	GetRunTimes(ctx context.Context, seed string) ([]time.Time, error)
+	GetRankHistory(ctx context.Context, seed, url string) ([]entity.RankEntry, error)
+	GetRun(ctx context.Context, seed string, runAt time.Time, maxRank int) ([]entity.RankEntry, error)
+}
+
+type Usecase struct {
+	rankRepo RankRepoItf
+}
+
+func New(rankRepo RankRepoItf) *Usecase {
+	return &Usecase{
+		rankRepo: rankRepo,
+	}
+}
+
+// Trajectory is the rank of a product in a seed over the runs it was seen in
+type Trajectory struct {
+	Seed    string
+	Url     string
+	Entries []entity.RankEntry // ordered by run
+}
+
+// TopNMove is a product that entered or left the top N between two runs.
+// A rank of 0 means outside the top N in that run.
+type TopNMove struct {
+	Url          string
+	PreviousRank int
+	CurrentRank  int
+}
+
+// TopNChanges compares the top N of the last two runs of a seed
+type TopNChanges struct {
+	Seed        string
+	N           int
+	PreviousRun time.Time
+	CurrentRun  time.Time
+	Entered     []TopNMove
+	Left        []TopNMove
+}
+
+// Seeds returns the seeds with rank history
+func (uc *Usecase) Seeds(ctx context.Context) ([]string, error) {
+	return uc.rankRepo.GetSeeds(ctx)
+}
+
+// Trajectories returns the rank trajectory of every product of a seed, or of
+// one product when url is not empty
+func (uc *Usecase) Trajectories(ctx context.Context, seed, url string) ([]Trajectory, error) {
+	entries, err := uc.rankRepo.GetRankHistory(ctx, seed, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rank history: %w", err)
+	}
+
+	// Entries come ordered by url then run, so each product is one run of entries
+	var trajectories []Trajectory
+	for _, entry := range entries {
+		if len(trajectories) == 0 || trajectories[len(trajectories)-1].Url != entry.Url {
+			trajectories = append(trajectories, Trajectory{Seed: seed, Url: entry.Url})
+		}
+		last := &trajectories[len(trajectories)-1]
+		last.Entries = append(last.Entries, entry)
+	}
+	return trajectories, nil
+}
+
+// LatestTopNChanges returns the products that entered or left the top n of
+// a seed between its last two runs. A seed with a single run has no changes.
+func (uc *Usecase) LatestTopNChanges(ctx context.Context, seed string, n int) (TopNChanges, error) {
+	changes := TopNChanges{Seed: seed, N: n}
+
+	runs, err := uc.rankRepo.GetRunTimes(ctx, seed)
+	if err != nil {
+		return changes, fmt.Errorf("failed to get runs: %w", err)
+	}
+	if len(runs) < 2 {
+		return changes, nil
+	}
+	changes.CurrentRun, changes.PreviousRun = runs[0], runs[1]
+
+	current, err := uc.rankRepo.GetRun(ctx, seed, changes.CurrentRun, n)
+	if err != nil {
+		return changes, fmt.Errorf("failed to get current run: %w", err)
+	}
+	previous, err := uc.rankRepo.GetRun(ctx, seed, changes.PreviousRun, n)
+	if err != nil {
+		return changes, fmt.Errorf("failed to get previous run: %w", err)
+	}
+
+	changes.Entered, changes.Left = diffTopN(previous, current)
+	return changes, nil
+}
+
+// diffTopN returns the urls only in current (entered) and only in previous (left)
+func diffTopN(previous, current []entity.RankEntry) ([]TopNMove, []TopNMove) {
+	previousRanks := ranksByUrl(previous)
+	currentRanks := ranksByUrl(current)
+
+	var entered, left []TopNMove
+	for url, rank := range currentRanks {
+		if _, ok := previousRanks[url]; !ok {
+			entered = append(entered, TopNMove{Url: url, CurrentRank: rank})
+		}
+	}
+	for url, rank := range previousRanks {
+		if _, ok := currentRanks[url]; !ok {
+			left = append(left, TopNMove{Url: url, PreviousRank: rank})
+		}
+	}
+	sort.Slice(entered, func(i, j int) bool { return entered[i].CurrentRank < entered[j].CurrentRank })
+	sort.Slice(left, func(i, j int) bool { return left[i].PreviousRank < left[j].PreviousRank })
+	return entered, left
+}
+
+func ranksByUrl(entries []entity.RankEntry) map[string]int {
+	ranks := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		if _, ok := ranks[entry.Url]; !ok {
+			ranks[entry.Url] = entry.Rank
+		}
+	}
+	return ranks
+}
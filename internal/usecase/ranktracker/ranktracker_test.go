@@ -0,0 +1,61 @@
+package ranktracker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func entries(urls ...string) []entity.RankEntry {
+	var ranked []entity.RankEntry
+	for i, url := range urls {
+		ranked = append(ranked, entity.RankEntry{Url: url, Rank: i + 1})
+	}
+	return ranked
+}
+
+func TestDiffTopN(t *testing.T) {
+	tests := []struct {
+		name        string
+		previous    []entity.RankEntry
+		current     []entity.RankEntry
+		wantEntered []TopNMove
+		wantLeft    []TopNMove
+	}{
+		{
+			name:     "same urls reordered",
+			previous: entries("a", "b", "c"),
+			current:  entries("c", "a", "b"),
+		},
+		{
+			name:        "entered and left in rank order",
+			previous:    entries("a", "b", "c", "d"),
+			current:     entries("e", "a", "f", "c"),
+			wantEntered: []TopNMove{{Url: "e", CurrentRank: 1}, {Url: "f", CurrentRank: 3}},
+			wantLeft:    []TopNMove{{Url: "b", PreviousRank: 2}, {Url: "d", PreviousRank: 4}},
+		},
+		{
+			name:        "first run",
+			current:     entries("a", "b"),
+			wantEntered: []TopNMove{{Url: "a", CurrentRank: 1}, {Url: "b", CurrentRank: 2}},
+		},
+		{
+			name:        "duplicate url keeps its best rank",
+			previous:    entries("a"),
+			current:     entries("b", "a", "b"),
+			wantEntered: []TopNMove{{Url: "b", CurrentRank: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entered, left := diffTopN(tt.previous, tt.current)
+			if !reflect.DeepEqual(entered, tt.wantEntered) {
+				t.Errorf("entered = %+v, want %+v", entered, tt.wantEntered)
+			}
+			if !reflect.DeepEqual(left, tt.wantLeft) {
+				t.Errorf("left = %+v, want %+v", left, tt.wantLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package scrappermanager
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is wrapped by RunResult.AbortErr when the breaker aborted the job
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// ErrSkipped is the error of the urls an aborted job never processed
+var ErrSkipped = errors.New("skipped, run aborted")
+
+type BreakerConfig struct {
+	// Threshold is the number of consecutive failures of the same class that
+	// trips the breaker. Zero or less disables the breaker.
+	Threshold int
+	// Cooldown is how long dispatching pauses before probing with a canary url.
+	Cooldown time.Duration
+}
+
+// circuitBreaker watches url outcomes and trips when the same error class
+// keeps failing, which usually means we are blocked or the page layout changed.
+type circuitBreaker struct {
+	mu          sync.Mutex
+	cfg         BreakerConfig
+	lastClass   string
+	consecutive int
+	tripped     bool
+}
+
+func newCircuitBreaker(cfg BreakerConfig) *circuitBreaker {
+	return &circuitBreaker{cfg: cfg}
+}
+
+// record feeds an outcome to the breaker. Any success resets the failure streak.
+func (b *circuitBreaker) record(outcome UrlOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if outcome.Err == nil {
+		b.lastClass = ""
+		b.consecutive = 0
+		return
+	}
+	if outcome.Class != b.lastClass {
+		b.lastClass = outcome.Class
+		b.consecutive = 0
+	}
+	b.consecutive++
+	if b.cfg.Threshold > 0 && b.consecutive >= b.cfg.Threshold {
+		b.tripped = true
+	}
+}
+
+// state reports whether the breaker is tripped and why
+func (b *circuitBreaker) state() (bool, string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.tripped {
+		return false, ""
+	}
+	return true, fmt.Sprintf("%d consecutive %s failures", b.consecutive, b.lastClass)
+}
+
+func (b *circuitBreaker) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastClass = ""
+	b.consecutive = 0
+	b.tripped = false
+}
@@ -0,0 +1,89 @@
+package scrappermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// fakeUrlRepo serves a fixed list of urls
+type fakeUrlRepo struct {
+	UrlRepoItf
+	urls []entity.Url
+}
+
+func (r *fakeUrlRepo) GetUrls(ctx context.Context) ([]entity.Url, error) {
+	return r.urls, nil
+}
+
+// failingScrapper fails every url before the page is opened
+type failingScrapper struct {
+	ScrapperRepoItf
+}
+
+func (s *failingScrapper) LaunchTab() error {
+	return errors.New("browser has been closed")
+}
+
+func newFailingUsecase(breaker BreakerConfig) *Usecase {
+	var urls []entity.Url
+	for i := 0; i < 5; i++ {
+		urls = append(urls, entity.Url{Url: fmt.Sprintf("https://www.tokopedia.com/shop/product-%d", i)})
+	}
+	return &Usecase{
+		urlRepo:     &fakeUrlRepo{urls: urls},
+		newScrapper: func() ScrapperRepoItf { return &failingScrapper{} },
+		NumWorkers:  1,
+		Breaker:     breaker,
+	}
+}
+
+func TestProductDetailsScrapperAbortSkipsRemainingUrls(t *testing.T) {
+	uc := newFailingUsecase(BreakerConfig{Threshold: 1})
+
+	result, err := uc.ProductDetailsScrapper(context.Background())
+	if err != nil {
+		t.Fatalf("ProductDetailsScrapper: %v", err)
+	}
+	if !errors.Is(result.AbortErr, ErrCircuitOpen) {
+		t.Errorf("AbortErr = %v, want %v", result.AbortErr, ErrCircuitOpen)
+	}
+	if len(result.Outcomes) != 5 || result.Failed+result.Skipped != 5 || result.Skipped == 0 {
+		t.Errorf("outcomes = %d, failed = %d, skipped = %d, want every url reported and some skipped",
+			len(result.Outcomes), result.Failed, result.Skipped)
+	}
+	if result.Processed() != result.Failed {
+		t.Errorf("Processed = %d, want the %d failed urls only", result.Processed(), result.Failed)
+	}
+	if joined := result.Err(); joined == nil || errors.Is(joined, ErrSkipped) {
+		t.Errorf("Err = %v, want the failures without the skipped urls", joined)
+	}
+}
+
+func TestProductDetailsScrapperCancelDuringCooldown(t *testing.T) {
+	uc := newFailingUsecase(BreakerConfig{Threshold: 1, Cooldown: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan *RunResult)
+	go func() {
+		result, _ := uc.ProductDetailsScrapper(ctx)
+		done <- result
+	}()
+
+	select {
+	case result := <-done:
+		if !errors.Is(result.AbortErr, context.DeadlineExceeded) {
+			t.Errorf("AbortErr = %v, want %v", result.AbortErr, context.DeadlineExceeded)
+		}
+		if len(result.Outcomes) != 5 || result.Skipped == 0 {
+			t.Errorf("outcomes = %d, skipped = %d, want every url reported and some skipped", len(result.Outcomes), result.Skipped)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ProductDetailsScrapper kept sleeping after the context was cancelled")
+	}
+}
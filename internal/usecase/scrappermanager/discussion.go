@@ -0,0 +1,73 @@
+package scrappermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"github.com/indragunawan95/topedcrawler/internal/repo/scrapper"
+)
+
+// scrapeDiscussions pages through the discussion (Q&A) tab of a product and
+// stores its questions and answers, up to the discussion pages of the seed
+// the product was discovered from. It returns the number of questions read.
+func (uc *Usecase) scrapeDiscussions(ctx context.Context, product entity.Product, seedName string) (int, error) {
+	seed, ok := uc.seeds.Seed(seedName)
+	if !ok || seed.DiscussionPages <= 0 {
+		return 0, nil
+	}
+
+	if err := uc.scrapperRepo.OpenDiscussionPage(discussionURL(product.Url)); err != nil {
+		return 0, fmt.Errorf("failed to open discussion page: %w", err)
+	}
+
+	read := 0
+	for page := 1; ; page++ {
+		questions, hasNext, err := uc.scrapperRepo.GetProductDiscussions()
+		if err != nil {
+			return read, fmt.Errorf("failed to read discussions page %d: %w", page, err)
+		}
+
+		for i := range questions {
+			questions[i].ProductID = product.ID
+			questions[i].ProductUrl = product.Url
+		}
+		if err := uc.discussionRepo.UpsertQuestions(ctx, questions); err != nil {
+			return read, fmt.Errorf("failed to save discussions: %w", err)
+		}
+		read += len(questions)
+
+		if !hasNext || page >= seed.DiscussionPages {
+			return read, nil
+		}
+
+		err = uc.scrapperRepo.NextDiscussionPage(uc.selectors.Pages["discussion"].Element("next_page"))
+		if errors.Is(err, scrapper.ErrSelectorMissing) {
+			return read, nil
+		}
+		if err != nil {
+			return read, fmt.Errorf("failed to open discussions page %d: %w", page+1, err)
+		}
+	}
+}
+
+// discussionURL returns the discussion page of a product, e.g.
+// https://www.tokopedia.com/samsung-official/galaxy-a54/talk
+func discussionURL(productURL string) string {
+	return productSubpage(productURL, "talk")
+}
+
+// productSubpage returns a tab of the product page, e.g. its review or talk page
+func productSubpage(productURL, tab string) string {
+	parsed, err := url.Parse(productURL)
+	if err != nil {
+		return strings.TrimSuffix(productURL, "/") + "/" + tab
+	}
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/" + tab
+	return parsed.String()
+}
@@ -0,0 +1,126 @@
+package scrappermanager
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// listingSetters copies an extracted card field onto the listing snapshot,
+// keyed by the field name used in the listing page of the selector file
+var listingSetters = map[string]func(snapshot *entity.ListingSnapshot, value string) error{
+	"url": func(snapshot *entity.ListingSnapshot, value string) error {
+		// Sponsored cards link to an ad redirect, keep it and the product behind it.
+		// An ad whose product cannot be resolved is kept without a product url.
+		if isAdURL(value) {
+			snapshot.AdUrl = value
+			snapshot.IsAd = true
+			productURL, err := resolveAdURL(value)
+			if err != nil {
+				log.Printf("Keeping unresolved ad card: %v", err)
+				return nil
+			}
+			value = productURL
+		}
+		snapshot.Url = value
+		return nil
+	},
+	"name": func(snapshot *entity.ListingSnapshot, value string) error {
+		snapshot.Name = value
+		return nil
+	},
+	"price": func(snapshot *entity.ListingSnapshot, value string) error {
+		price, err := entity.ParseIDR(value, false)
+		if err != nil {
+			return err
+		}
+		snapshot.Price = price
+		return nil
+	},
+	"shop_city": func(snapshot *entity.ListingSnapshot, value string) error {
+		snapshot.ShopCity = value
+		return nil
+	},
+	"rating": func(snapshot *entity.ListingSnapshot, value string) error {
+		rating, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 32)
+		if err != nil {
+			return fmt.Errorf("error converting rating: %w", err)
+		}
+		snapshot.Rating = float32(rating)
+		return nil
+	},
+	"sold_count": func(snapshot *entity.ListingSnapshot, value string) error {
+		snapshot.SoldCountText = value
+		count, err := parseIndonesianCount(value)
+		if err != nil {
+			return err
+		}
+		snapshot.SoldCount = count
+		return nil
+	},
+	"ad_badge": func(snapshot *entity.ListingSnapshot, value string) error {
+		// Only sponsored cards carry the badge, any text marks the card
+		snapshot.IsAd = true
+		return nil
+	},
+}
+
+// parseListingCard turns the field values of one card into a snapshot.
+// Optional fields that fail to parse are left empty, a card without a
+// required field, e.g. its url, is an error.
+func parseListingCard(fields []entity.FieldSelector, values []entity.FieldValue) (entity.ListingSnapshot, error) {
+	snapshot := entity.ListingSnapshot{}
+	for i, field := range fields {
+		err := values[i].Err
+		if set, ok := listingSetters[field.Name]; ok && err == nil {
+			err = set(&snapshot, values[i].Value)
+		}
+		if err != nil && field.Required {
+			return snapshot, fmt.Errorf("failed to get card %s: %w", field.Name, err)
+		}
+	}
+	return snapshot, nil
+}
+
+// adHost serves the redirect links of sponsored listing cards, e.g.
+// https://ta.tokopedia.com/promo/v1/clicks/8a-xXM.../?r=https%3A%2F%2Fwww.tokopedia.com%2Fshop%2Fproduct
+const adHost = "ta.tokopedia.com"
+
+func isAdURL(link string) bool {
+	parsed, err := url.Parse(link)
+	return err == nil && parsed.Host == adHost
+}
+
+// resolveAdURL returns the product url an ad redirect link points to, taken
+// from its r query parameter
+func resolveAdURL(link string) (string, error) {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("invalid ad url: %w", err)
+	}
+	target := parsed.Query().Get("r")
+	if target == "" {
+		return "", fmt.Errorf("ad url %s has no target", link)
+	}
+	resolved, err := url.Parse(target)
+	if err != nil || resolved.Host == "" || resolved.Host == adHost {
+		return "", fmt.Errorf("ad url %s has an invalid target %q", link, target)
+	}
+	return resolved.String(), nil
+}
+
+// canonicalProductURL drops the query and fragment of a product url, listing
+// links carry tracking parameters that change from run to run
+func canonicalProductURL(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
@@ -0,0 +1,70 @@
+package scrappermanager
+
+import (
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestResolveAdURL(t *testing.T) {
+	tests := []struct {
+		link    string
+		want    string
+		wantErr bool
+	}{
+		{
+			"https://ta.tokopedia.com/promo/v1/clicks/8a-xXM/?r=https%3A%2F%2Fwww.tokopedia.com%2Fsamsung-official%2Fgalaxy-a54",
+			"https://www.tokopedia.com/samsung-official/galaxy-a54",
+			false,
+		},
+		{"https://ta.tokopedia.com/promo/v1/clicks/8a-xXM/", "", true},
+		{"https://ta.tokopedia.com/promo/v1/clicks/8a-xXM/?r=galaxy-a54", "", true},
+		{"https://ta.tokopedia.com/promo/v1/clicks/8a-xXM/?r=https%3A%2F%2Fta.tokopedia.com%2Fpromo", "", true},
+	}
+	for _, tt := range tests {
+		got, err := resolveAdURL(tt.link)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("resolveAdURL(%q) = %q, %v, want %q, wantErr %v", tt.link, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseListingCardAds(t *testing.T) {
+	fields := []entity.FieldSelector{{Name: "url", Required: true}, {Name: "name"}}
+	tests := []struct {
+		name      string
+		url       string
+		wantUrl   string
+		wantAdUrl string
+		wantAd    bool
+	}{
+		{
+			"organic",
+			"https://www.tokopedia.com/samsung-official/galaxy-a54",
+			"https://www.tokopedia.com/samsung-official/galaxy-a54", "", false,
+		},
+		{
+			"resolved ad",
+			"https://ta.tokopedia.com/promo/v1/clicks/8a/?r=https%3A%2F%2Fwww.tokopedia.com%2Fshop%2Fproduct",
+			"https://www.tokopedia.com/shop/product",
+			"https://ta.tokopedia.com/promo/v1/clicks/8a/?r=https%3A%2F%2Fwww.tokopedia.com%2Fshop%2Fproduct", true,
+		},
+		{
+			"unresolved ad is kept",
+			"https://ta.tokopedia.com/promo/v1/clicks/8a/",
+			"", "https://ta.tokopedia.com/promo/v1/clicks/8a/", true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := []entity.FieldValue{{Name: "url", Value: tt.url}, {Name: "name", Value: "Galaxy A54"}}
+			got, err := parseListingCard(fields, values)
+			if err != nil {
+				t.Fatalf("parseListingCard: %v", err)
+			}
+			if got.Url != tt.wantUrl || got.AdUrl != tt.wantAdUrl || got.IsAd != tt.wantAd || got.Name != "Galaxy A54" {
+				t.Errorf("parseListingCard = %+v", got)
+			}
+		})
+	}
+}
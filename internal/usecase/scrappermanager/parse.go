@@ -0,0 +1,92 @@
+package scrappermanager
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/repo/scrapper"
+)
+
+// machineNumber matches numbers coming from structured data, e.g. 1299000 or 1299000.5
+var machineNumber = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// parsePercent parses "12%", "12,5%" or "12.5" into a number
+func parsePercent(text string) (float32, error) {
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "%"))
+	text = strings.ReplaceAll(text, ",", ".")
+	value, err := strconv.ParseFloat(text, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q: %w", text, err)
+	}
+	return float32(value), nil
+}
+
+// parseIndonesianNumber parses a number written with dots for thousands and
+// a comma for decimals, e.g. "1.500" or "1,5"
+func parseIndonesianNumber(text string) (float64, error) {
+	text = strings.ReplaceAll(strings.TrimSpace(text), ".", "")
+	return strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+}
+
+// isStructured tells whether a field value was read from structured data
+// rather than from text shown on the page
+func isStructured(source string) bool {
+	return source != "" && source != scrapper.SourceDOM
+}
+
+// Count tokens, tried in order: a number with an abbreviation like "1rb+" or
+// "2,5jt", a number next to what it counts like "1.234 rating" or
+// "Terjual 250+", and a lone number like "Sisa 3". "Rating 4.9" is a score,
+// so rating only anchors the number before it.
+var (
+	abbreviatedCount = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*(rb|ribu|jt|juta|k)\b`)
+	countBeforeWord  = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\+?\s*(?:terjual|rating|ulasan|review|barang|pcs)`)
+	countAfterWord   = regexp.MustCompile(`(?:terjual|ulasan|stok|sisa)\s*:?\s*(\d+(?:[.,]\d+)*)`)
+	anyNumber        = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+)
+
+var countMultipliers = map[string]float64{
+	"":     1,
+	"k":    1e3,
+	"rb":   1e3,
+	"ribu": 1e3,
+	"jt":   1e6,
+	"juta": 1e6,
+}
+
+// parseIndonesianCount parses abbreviated counts such as "Terjual 1rb+",
+// "250+ rating" or "2,5jt" into their lower bound, e.g. 1000, 250 and 2500000.
+// Other numbers of the text are skipped, so "4.9 (1.234 rating)" is 1234.
+func parseIndonesianCount(text string) (int64, error) {
+	text = strings.ToLower(text)
+
+	var number, suffix string
+	if match := abbreviatedCount.FindStringSubmatch(text); match != nil {
+		number, suffix = match[1], match[2]
+	} else if match := countBeforeWord.FindStringSubmatch(text); match != nil {
+		number = match[1]
+	} else if match := countAfterWord.FindStringSubmatch(text); match != nil {
+		number = match[1]
+	} else if numbers := anyNumber.FindAllString(text, -1); len(numbers) == 1 {
+		number = numbers[0]
+	} else if len(numbers) == 0 {
+		return 0, fmt.Errorf("no count in %q", text)
+	} else {
+		return 0, fmt.Errorf("ambiguous count in %q", text)
+	}
+
+	if suffix == "" {
+		// Without an abbreviation dots are thousands separators, e.g. "1.234"
+		number = strings.ReplaceAll(number, ".", "")
+	}
+	number = strings.ReplaceAll(number, ",", ".")
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid count %q: %w", text, err)
+	}
+	return int64(math.Round(value * countMultipliers[suffix])), nil
+}
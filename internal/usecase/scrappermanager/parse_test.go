@@ -0,0 +1,55 @@
+package scrappermanager
+
+import (
+	"testing"
+)
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    float32
+		wantErr bool
+	}{
+		{"12%", 12, false},
+		{"12,5%", 12.5, false},
+		{" 12.5 ", 12.5, false},
+		{"diskon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parsePercent(tt.text)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parsePercent(%q) = %v, %v, want %v, wantErr %v", tt.text, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseIndonesianCount(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    int64
+		wantErr bool
+	}{
+		{"Terjual 1rb+", 1000, false},
+		{"Terjual 250+", 250, false},
+		{"250+ rating", 250, false},
+		{"2,5jt", 2500000, false},
+		{"1,2 rb terjual", 1200, false},
+		{"10k", 10000, false},
+		{"1.234", 1234, false},
+		{"4.9 (1.234 rating)", 1234, false},
+		{"4.9 (12rb rating)", 12000, false},
+		{"4,8 · 530 ulasan", 530, false},
+		{"Rating 4.9 · Terjual 100+", 100, false},
+		{"Stok: 120", 120, false},
+		{"Sisa 3", 3, false},
+		{"Min. pembelian 2 barang", 2, false},
+		{"Terjual", 0, true},
+		{"4.9 5.0", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseIndonesianCount(tt.text)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseIndonesianCount(%q) = %d, %v, want %d, wantErr %v", tt.text, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package scrappermanager
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+const (
+	// A brand named in the title, and one implied by a model series
+	brandAliasConfidence  = 0.9
+	brandSeriesConfidence = 0.75
+	// A model ended by a memory, network, warranty or stop word, and one
+	// running to the end of the title or the word limit
+	boundedModelConfidence   = 0.8
+	unboundedModelConfidence = 0.5
+	// A spec of the detail list, and a "Key: value" hint of the description
+	detailSpecConfidence      = 0.95
+	descriptionSpecConfidence = 0.85
+	// A spec brand missing from the dictionary is kept with a lower confidence
+	unknownBrandFactor = 0.8
+	// Title and spec agreeing raise the confidence, disagreeing lower the spec one
+	agreementBonus  = 0.05
+	conflictPenalty = 0.8
+	maxModelWords   = 4
+)
+
+var (
+	// A trailing "+" belongs to the word, e.g. "Pro+", it does not join "8+256GB"
+	titleWord    = regexp.MustCompile(`[\p{L}\p{N}]+\+*`)
+	memoryAmount = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*(mb|gb|tb)?`)
+	// memoryToken ends the model name even when no memory pattern reads it, e.g. "4GB"
+	memoryToken = regexp.MustCompile(`(?i)\b\d+(?:[.,]\d+)?\s*(?:mb|gb|tb)\b`)
+)
+
+// phoneParser parses phone attributes with the compiled phone dictionary
+type phoneParser struct {
+	categories  map[string]bool
+	seeds       map[string]bool
+	accessories [][]string // lowercased words of each accessory phrase
+	bonusWords  map[string]bool
+	aliases     map[string]string // lowercased word to brand name
+	series      map[string]string
+	stopWords   map[string]bool
+	memory      []memoryMatcher
+	networks    []valueMatcher
+	warranties  []valueMatcher
+	specKeys    entity.PhoneSpecKeys
+}
+
+type memoryMatcher struct {
+	entity.MemoryPattern
+	re *regexp.Regexp
+}
+
+type valueMatcher struct {
+	entity.ValuePattern
+	re *regexp.Regexp
+}
+
+// phoneAttribute is one parsed attribute, value is empty when not found
+type phoneAttribute struct {
+	value      string
+	number     *float64
+	confidence float64
+}
+
+// newPhoneParser compiles the dictionary, its patterns are validated when loaded
+func newPhoneParser(dict entity.PhoneDictionary) *phoneParser {
+	p := &phoneParser{
+		categories: lowerSet(dict.Categories),
+		seeds:      lowerSet(dict.Seeds),
+		aliases:    make(map[string]string),
+		series:     make(map[string]string),
+		bonusWords: lowerSet(dict.BonusWords),
+		stopWords:  lowerSet(dict.ModelStopWords),
+		specKeys:   dict.SpecKeys,
+	}
+	for _, accessory := range dict.AccessoryWords {
+		if words := titleWord.FindAllString(strings.ToLower(accessory), -1); len(words) > 0 {
+			p.accessories = append(p.accessories, words)
+		}
+	}
+	for _, brand := range dict.Brands {
+		for _, alias := range brand.Aliases {
+			p.aliases[strings.ToLower(alias)] = brand.Name
+		}
+		for _, series := range brand.Series {
+			p.series[strings.ToLower(series)] = brand.Name
+		}
+	}
+	for _, memory := range dict.Memory {
+		p.memory = append(p.memory, memoryMatcher{MemoryPattern: memory, re: regexp.MustCompile(memory.Pattern)})
+	}
+	for _, network := range dict.Networks {
+		p.networks = append(p.networks, valueMatcher{ValuePattern: network, re: regexp.MustCompile(network.Pattern)})
+	}
+	for _, warranty := range dict.Warranties {
+		p.warranties = append(p.warranties, valueMatcher{ValuePattern: warranty, re: regexp.MustCompile(warranty.Pattern)})
+	}
+	return p
+}
+
+func lowerSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[strings.ToLower(word)] = true
+	}
+	return set
+}
+
+// enrichPhone parses the phone attributes of a product of a phone category or
+// seed, nil for other products or when nothing was found
+func (uc *Usecase) enrichPhone(product entity.Product, seed string) *entity.PhoneAttributes {
+	if uc.phoneParser == nil || !uc.phoneParser.isPhone(product, seed) {
+		return nil
+	}
+	attributes, ok := uc.phoneParser.parse(product)
+	if !ok {
+		return nil
+	}
+	return &attributes
+}
+
+func (p *phoneParser) isPhone(product entity.Product, seed string) bool {
+	if p.isAccessory(product.Name) {
+		return false
+	}
+	if p.seeds[strings.ToLower(seed)] {
+		return true
+	}
+	for _, category := range product.Categories {
+		if p.categories[strings.ToLower(category.Name)] {
+			return true
+		}
+	}
+	return false
+}
+
+// isAccessory tells whether the title names an accessory word before any
+// bonus word, e.g. "Case Samsung A54" but not "Samsung A54 Free Case"
+func (p *phoneParser) isAccessory(title string) bool {
+	words := titleWord.FindAllString(strings.ToLower(title), -1)
+	for i, word := range words {
+		if p.bonusWords[word] {
+			return false
+		}
+		for _, accessory := range p.accessories {
+			if hasWordsAt(words, i, accessory) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasWordsAt(words []string, i int, phrase []string) bool {
+	if i+len(phrase) > len(words) {
+		return false
+	}
+	for j, word := range phrase {
+		if words[i+j] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// parse reads the attributes from the title, then from the specs. A spec
+// confirming the title raises the confidence, a spec contradicting it wins.
+func (p *phoneParser) parse(product entity.Product) (entity.PhoneAttributes, bool) {
+	brand, model := p.titleBrandModel(product.Name)
+	ram, storage := p.titleMemory(product.Name)
+	network := matchValue(p.networks, product.Name, 0)
+	warranty := matchValue(p.warranties, product.Name, 0)
+
+	for _, spec := range product.Specs {
+		confidence := detailSpecConfidence
+		if spec.Source == entity.SpecSourceDescription {
+			confidence = descriptionSpecConfidence
+		}
+		switch {
+		case hasKey(p.specKeys.Brand, spec.Key):
+			brand = combineAttribute(brand, p.specBrand(spec.Value, confidence))
+		case hasKey(p.specKeys.Ram, spec.Key):
+			ram = combineAttribute(ram, specMemory(spec.RawValue, confidence))
+		case hasKey(p.specKeys.Storage, spec.Key):
+			storage = combineAttribute(storage, specMemory(spec.RawValue, confidence))
+		case hasKey(p.specKeys.Network, spec.Key):
+			network = combineAttribute(network, matchValue(p.networks, spec.RawValue, confidence))
+		case hasKey(p.specKeys.Warranty, spec.Key):
+			warranty = combineAttribute(warranty, matchValue(p.warranties, spec.RawValue, confidence))
+		}
+	}
+
+	found := false
+	for _, attribute := range []phoneAttribute{brand, model, ram, storage, network, warranty} {
+		found = found || attribute.value != ""
+	}
+	return entity.PhoneAttributes{
+		Brand:              brand.value,
+		BrandConfidence:    roundConfidence(brand.confidence),
+		ModelName:          model.value,
+		ModelConfidence:    roundConfidence(model.confidence),
+		RamGB:              ram.number,
+		RamConfidence:      roundConfidence(ram.confidence),
+		StorageGB:          storage.number,
+		StorageConfidence:  roundConfidence(storage.confidence),
+		Network:            network.value,
+		NetworkConfidence:  roundConfidence(network.confidence),
+		Warranty:           warranty.value,
+		WarrantyConfidence: roundConfidence(warranty.confidence),
+	}, found
+}
+
+// titleBrandModel finds the first brand alias or series of the title, the
+// model is the words from there, e.g. "Galaxy A54" of "Samsung Galaxy A54 5G"
+func (p *phoneParser) titleBrandModel(title string) (brand, model phoneAttribute) {
+	words := titleWord.FindAllStringIndex(title, -1)
+	start := -1
+	for i, word := range words {
+		lower := strings.ToLower(title[word[0]:word[1]])
+		if name, ok := p.aliases[lower]; ok {
+			brand = phoneAttribute{value: name, confidence: brandAliasConfidence}
+			start = i + 1
+			break
+		}
+		if name, ok := p.series[lower]; ok {
+			brand = phoneAttribute{value: name, confidence: brandSeriesConfidence}
+			start = i
+			break
+		}
+	}
+	if start < 0 || start == len(words) {
+		return brand, model
+	}
+
+	end := p.titleCutoff(title, words[start][0])
+	bounded := false
+	var parts []string
+	for _, word := range words[start:] {
+		text := title[word[0]:word[1]]
+		if word[0] >= end || p.stopWords[strings.ToLower(text)] {
+			bounded = true
+			break
+		}
+		if len(parts) == maxModelWords {
+			break
+		}
+		parts = append(parts, text)
+	}
+	if len(parts) == 0 {
+		return brand, model
+	}
+
+	model = phoneAttribute{value: strings.Join(parts, " "), confidence: unboundedModelConfidence}
+	if bounded {
+		model.confidence = boundedModelConfidence
+	}
+	return brand, model
+}
+
+// titleCutoff returns where the first memory token, memory, network or
+// warranty match at or after from starts, the end of the title without one
+func (p *phoneParser) titleCutoff(title string, from int) int {
+	patterns := []*regexp.Regexp{memoryToken}
+	for _, m := range p.memory {
+		patterns = append(patterns, m.re)
+	}
+	for _, m := range append(append([]valueMatcher{}, p.networks...), p.warranties...) {
+		patterns = append(patterns, m.re)
+	}
+
+	end := len(title)
+	for _, re := range patterns {
+		for _, match := range re.FindAllStringIndex(title, -1) {
+			if match[0] >= from && match[0] < end {
+				end = match[0]
+				break
+			}
+		}
+	}
+	return end
+}
+
+// titleMemory applies the memory patterns in order, each filling what is
+// still unknown. Text read by an earlier pattern is not read again, so
+// "RAM 12GB 256GB" gives 12 GB of RAM and 256 GB of storage.
+func (p *phoneParser) titleMemory(title string) (ram, storage phoneAttribute) {
+	var used [][]int
+	for _, m := range p.memory {
+		needRam := m.Ram > 0 && ram.value == ""
+		needStorage := m.Storage > 0 && storage.value == ""
+		if !needRam && !needStorage {
+			continue
+		}
+
+		for _, match := range m.re.FindAllStringSubmatchIndex(title, -1) {
+			if overlaps(used, match[0], match[1]) {
+				continue
+			}
+			group := func(i int) string {
+				if i == 0 || match[2*i] < 0 {
+					return ""
+				}
+				return title[match[2*i]:match[2*i+1]]
+			}
+			if needRam {
+				ram = memoryAttribute(group(m.Ram), "gb", m.Confidence)
+			}
+			if needStorage {
+				storage = memoryAttribute(group(m.Storage), group(m.Unit), m.Confidence)
+			}
+			used = append(used, match[:2])
+			break
+		}
+	}
+	return ram, storage
+}
+
+func overlaps(spans [][]int, start, end int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+// memoryAttribute converts an amount in MB, GB or TB to GB, GB when unit is empty
+func memoryAttribute(amount, unit string, confidence float64) phoneAttribute {
+	number, err := strconv.ParseFloat(strings.ReplaceAll(amount, ",", "."), 64)
+	if err != nil || number <= 0 {
+		return phoneAttribute{}
+	}
+	switch strings.ToLower(unit) {
+	case "mb":
+		number /= 1024
+	case "tb":
+		number *= 1024
+	}
+	return phoneAttribute{
+		value:      strconv.FormatFloat(number, 'f', -1, 64),
+		number:     &number,
+		confidence: confidence,
+	}
+}
+
+// specMemory reads a memory spec such as "8 GB" or "1TB"
+func specMemory(value string, confidence float64) phoneAttribute {
+	match := memoryAmount.FindStringSubmatch(value)
+	if match == nil {
+		return phoneAttribute{}
+	}
+	return memoryAttribute(match[1], match[2], confidence)
+}
+
+// specBrand maps a brand spec to the dictionary brand name
+func (p *phoneParser) specBrand(value string, confidence float64) phoneAttribute {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return phoneAttribute{}
+	}
+	if name, ok := p.aliases[strings.ToLower(value)]; ok {
+		return phoneAttribute{value: name, confidence: confidence}
+	}
+	return phoneAttribute{value: value, confidence: confidence * unknownBrandFactor}
+}
+
+// matchValue returns the value of the first matching pattern. A confidence
+// above 0 replaces the one of the pattern, e.g. for a spec value.
+func matchValue(matchers []valueMatcher, text string, confidence float64) phoneAttribute {
+	for _, m := range matchers {
+		if !m.re.MatchString(text) {
+			continue
+		}
+		if confidence == 0 {
+			confidence = m.Confidence
+		}
+		return phoneAttribute{value: m.Value, confidence: confidence}
+	}
+	return phoneAttribute{}
+}
+
+// combineAttribute merges a spec reading into the current one
+func combineAttribute(current, spec phoneAttribute) phoneAttribute {
+	switch {
+	case spec.value == "":
+		return current
+	case current.value == "":
+		return spec
+	case strings.EqualFold(current.value, spec.value):
+		spec.confidence = math.Min(1, math.Max(current.confidence, spec.confidence)+agreementBonus)
+		return spec
+	}
+	spec.confidence *= conflictPenalty
+	return spec
+}
+
+func hasKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func roundConfidence(confidence float64) float64 {
+	return math.Round(confidence*100) / 100
+}
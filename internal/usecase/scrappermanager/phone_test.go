@@ -0,0 +1,102 @@
+package scrappermanager
+
+import (
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"github.com/indragunawan95/topedcrawler/internal/repo/dictionary"
+)
+
+func testPhoneParser(t *testing.T) *phoneParser {
+	t.Helper()
+	dict, err := dictionary.LoadPhoneDictionary("../../../files/config/phones.yaml")
+	if err != nil {
+		t.Fatalf("LoadPhoneDictionary: %v", err)
+	}
+	return newPhoneParser(*dict)
+}
+
+func TestPhoneParserTitle(t *testing.T) {
+	p := testPhoneParser(t)
+	tests := []struct {
+		title       string
+		brand       string
+		model       string
+		ram         float64 // 0 when not found
+		storage     float64
+		network     string
+		warrantyVal string
+	}{
+		{"Samsung Galaxy A54 5G 8/256GB Garansi Resmi", "Samsung", "Galaxy A54", 8, 256, "5G", "resmi"},
+		{"Infinix Hot 40i 8+256GB", "Infinix", "Hot 40i", 8, 256, "", ""},
+		{"Samsung A05 4GB 64GB", "Samsung", "A05", 4, 64, "", ""},
+		{"Xiaomi Redmi Note 13 RAM 8 ROM 256 GB", "Xiaomi", "Redmi Note 13", 8, 256, "", ""},
+		{"iPhone 15 Pro 1TB Garansi Resmi iBox", "Apple", "iPhone 15 Pro", 0, 1024, "", "resmi"},
+		{"Oppo Reno 11 RAM 12GB 256GB", "Oppo", "Reno 11", 12, 256, "", ""},
+		{"Xiaomi Redmi Note 13 Pro+ 5G 12/512GB", "Xiaomi", "Redmi Note 13 Pro+", 12, 512, "5G", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got, ok := p.parse(entity.Product{Name: tt.title})
+			if !ok {
+				t.Fatal("parse found nothing")
+			}
+			if got.Brand != tt.brand || got.ModelName != tt.model {
+				t.Errorf("brand, model = %q, %q, want %q, %q", got.Brand, got.ModelName, tt.brand, tt.model)
+			}
+			if ram := value(got.RamGB); ram != tt.ram {
+				t.Errorf("ram = %v, want %v", ram, tt.ram)
+			}
+			if storage := value(got.StorageGB); storage != tt.storage {
+				t.Errorf("storage = %v, want %v", storage, tt.storage)
+			}
+			if got.Network != tt.network || got.Warranty != tt.warrantyVal {
+				t.Errorf("network, warranty = %q, %q, want %q, %q", got.Network, got.Warranty, tt.network, tt.warrantyVal)
+			}
+		})
+	}
+}
+
+func TestPhoneParserIsPhone(t *testing.T) {
+	p := testPhoneParser(t)
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"Samsung Galaxy A54 5G 8/256GB", true},
+		{"Samsung A54 8/256GB Free Case + Tempered Glass", true},
+		{"Case Samsung A54 Silikon", false},
+		{"Tempered Glass Samsung A54 Full Cover", false},
+		{"Charger Samsung 25W Original", false},
+		{"Samsung Kabel Data Type C", false},
+	}
+	for _, tt := range tests {
+		if got := p.isPhone(entity.Product{Name: tt.title}, "handphone"); got != tt.want {
+			t.Errorf("isPhone(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestPhoneParserSpecWins(t *testing.T) {
+	p := testPhoneParser(t)
+	got, _ := p.parse(entity.Product{
+		Name: "Samsung Galaxy A54 5G 8/256GB",
+		Specs: []entity.ProductSpec{
+			{Key: "ram", RawValue: "8 GB"},
+			{Key: "storage", RawValue: "128 GB"},
+		},
+	})
+	if value(got.RamGB) != 8 || got.RamConfidence <= 0.9 {
+		t.Errorf("agreeing ram = %v at %v, want 8 above 0.9", value(got.RamGB), got.RamConfidence)
+	}
+	if value(got.StorageGB) != 128 {
+		t.Errorf("conflicting storage = %v, want the spec 128", value(got.StorageGB))
+	}
+}
+
+func value(number *float64) float64 {
+	if number == nil {
+		return 0
+	}
+	return *number
+}
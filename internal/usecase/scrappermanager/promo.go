@@ -0,0 +1,125 @@
+package scrappermanager
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// promoSetters copies extracted promotion fields onto the product, keyed by
+// the field name used in the selector file, see productSetters
+var promoSetters = map[string]func(product *entity.Product, value, source string) error{
+	"cashback": func(product *entity.Product, value, source string) error {
+		// Structured data reports 0 without cashback
+		if strings.TrimSpace(value) == "0" {
+			return nil
+		}
+		product.CashbackText = value
+		percent, ok := parseCashbackPercent(value)
+		if ok {
+			product.CashbackPercent = percent
+		}
+		return nil
+	},
+	"free_shipping": func(product *entity.Product, value, source string) error {
+		product.FreeShipping = parseFreeShipping(value)
+		return nil
+	},
+	"campaign_name": func(product *entity.Product, value, source string) error {
+		product.CampaignName = value
+		return nil
+	},
+	"campaign_price": func(product *entity.Product, value, source string) error {
+		price, err := entity.ParseIDR(value, isStructured(source))
+		if err != nil {
+			return err
+		}
+		product.CampaignPrice = price
+		return nil
+	},
+	"campaign_ends_at": func(product *entity.Product, value, source string) error {
+		// Structured data reports 0 when no campaign is running
+		if strings.TrimSpace(value) == "0" {
+			return nil
+		}
+		endsAt, err := parseCampaignEnd(value, time.Now())
+		if err != nil {
+			return err
+		}
+		product.CampaignEndsAt = &endsAt
+		return nil
+	},
+	"campaign_quota": func(product *entity.Product, value, source string) error {
+		product.CampaignQuotaText = value
+		quota, err := parseStock(value)
+		if err != nil {
+			return err
+		}
+		product.CampaignQuota = quota
+		return nil
+	},
+}
+
+var cashbackPercentPattern = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*%`)
+
+// parseCashbackPercent reads "Cashback 5%" or a plain percentage from
+// structured data. Cashback in rupiah, e.g. "Cashback Rp10rb", has no percentage.
+func parseCashbackPercent(value string) (float32, bool) {
+	if match := cashbackPercentPattern.FindStringSubmatch(value); match != nil {
+		percent, err := parsePercent(match[1])
+		return percent, err == nil
+	}
+	if machineNumber.MatchString(strings.TrimSpace(value)) {
+		percent, err := parsePercent(value)
+		return percent, err == nil && percent > 0
+	}
+	return 0, false
+}
+
+// parseFreeShipping reads a boolean or a Bebas Ongkir program type from
+// structured data, 0 meaning none. Any badge text found on the page means free shipping.
+func parseFreeShipping(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "0":
+		return false
+	}
+	return true
+}
+
+var (
+	countdownPattern = regexp.MustCompile(`(\d{1,2}):(\d{2}):(\d{2})`)
+	daysLeftPattern  = regexp.MustCompile(`(?i)(\d+)\s*hari`)
+)
+
+// parseCampaignEnd reads a unix timestamp from structured data, or a
+// countdown shown on the page such as "Berakhir dalam 02:13:45" or
+// "Berakhir dalam 2 hari 03:00:00", counted from now
+func parseCampaignEnd(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds > 0 {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	var left time.Duration
+	found := false
+	if match := daysLeftPattern.FindStringSubmatch(value); match != nil {
+		days, _ := strconv.Atoi(match[1])
+		left += time.Duration(days) * 24 * time.Hour
+		found = true
+	}
+	if match := countdownPattern.FindStringSubmatch(value); match != nil {
+		hours, _ := strconv.Atoi(match[1])
+		minutes, _ := strconv.Atoi(match[2])
+		seconds, _ := strconv.Atoi(match[3])
+		left += time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		found = true
+	}
+	if !found {
+		return time.Time{}, fmt.Errorf("no campaign end in %q", value)
+	}
+	return now.Add(left).UTC().Truncate(time.Second), nil
+}
@@ -0,0 +1,64 @@
+package scrappermanager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCampaignEnd(t *testing.T) {
+	now := time.Date(2024, 3, 11, 10, 0, 0, 500, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"1710151200", time.Unix(1710151200, 0).UTC(), false},
+		{"Berakhir dalam 02:13:45", time.Date(2024, 3, 11, 12, 13, 45, 0, time.UTC), false},
+		{"Berakhir dalam 2 hari 03:00:00", time.Date(2024, 3, 13, 13, 0, 0, 0, time.UTC), false},
+		{"Berakhir dalam 1 hari", time.Date(2024, 3, 12, 10, 0, 0, 0, time.UTC), false},
+		{"Segera berakhir", time.Time{}, true},
+		{"0", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCampaignEnd(tt.value, now)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("parseCampaignEnd(%q) = %v, %v, want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseCashbackPercent(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   float32
+		wantOK bool
+	}{
+		{"Cashback 5%", 5, true},
+		{"Cashback 2,5%", 2.5, true},
+		{"10", 10, true},
+		{"0", 0, false},
+		{"Cashback Rp10rb", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseCashbackPercent(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseCashbackPercent(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseFreeShipping(t *testing.T) {
+	tests := map[string]bool{
+		"":             false,
+		"0":            false,
+		"false":        false,
+		"true":         true,
+		"1":            true,
+		"Bebas Ongkir": true,
+	}
+	for value, want := range tests {
+		if got := parseFreeShipping(value); got != want {
+			t.Errorf("parseFreeShipping(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package scrappermanager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"github.com/indragunawan95/topedcrawler/internal/repo/scrapper"
+)
+
+// Error classes used to group failures in a RunResult
+const (
+	ClassNavigationTimeout = "navigation_timeout"
+	ClassNotFound          = "not_found"
+	ClassSelectorMissing   = "selector_missing"
+	ClassBlocked           = "blocked"
+	ClassBrowserCrashed    = "browser_crashed"
+	ClassOther             = "other"
+	// ClassSkipped marks urls an aborted job never processed, they are not failures
+	ClassSkipped = "skipped"
+)
+
+// UrlOutcome is the result of processing a single product url
+type UrlOutcome struct {
+	Url      entity.Url
+	Err      error
+	Class    string                  // empty when Err is nil
+	Fields   entity.FieldExtractions // nil when the page could not be scraped
+	Duration time.Duration
+}
+
+// RunResult summarises a whole ProductDetailsScrapper run
+type RunResult struct {
+	Outcomes     []UrlOutcome
+	Succeeded    int
+	Failed       int
+	Skipped      int // not processed because the job was aborted
+	ErrorClasses map[string]int
+	// FieldStatuses counts extraction statuses per product field
+	FieldStatuses map[string]map[entity.FieldStatus]int
+	// SelectorMatches counts which source or selector alternative matched per product field
+	SelectorMatches map[string]map[string]int
+	// AbortErr is set when the circuit breaker stopped the job early
+	AbortErr error
+	Duration time.Duration
+}
+
+func newRunResult() *RunResult {
+	return &RunResult{
+		ErrorClasses:    make(map[string]int),
+		FieldStatuses:   make(map[string]map[entity.FieldStatus]int),
+		SelectorMatches: make(map[string]map[string]int),
+	}
+}
+
+func (r *RunResult) add(outcome UrlOutcome) {
+	r.Outcomes = append(r.Outcomes, outcome)
+	if errors.Is(outcome.Err, ErrSkipped) {
+		r.Skipped++
+		return
+	}
+	for name, field := range outcome.Fields {
+		if r.FieldStatuses[name] == nil {
+			r.FieldStatuses[name] = make(map[entity.FieldStatus]int)
+		}
+		r.FieldStatuses[name][field.Status]++
+
+		matched := field.Matched
+		if matched == "" {
+			matched = field.Source
+		}
+		if matched == "" {
+			continue
+		}
+		if r.SelectorMatches[name] == nil {
+			r.SelectorMatches[name] = make(map[string]int)
+		}
+		r.SelectorMatches[name][matched]++
+	}
+	if outcome.Err == nil {
+		r.Succeeded++
+		return
+	}
+	r.Failed++
+	r.ErrorClasses[outcome.Class]++
+}
+
+// Err combines every url failure into a single error, nil if all urls succeeded
+func (r *RunResult) Err() error {
+	var errs []error
+	for _, outcome := range r.Outcomes {
+		if outcome.Err != nil && !errors.Is(outcome.Err, ErrSkipped) {
+			errs = append(errs, fmt.Errorf("%s: %w", outcome.Url.Url, outcome.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Processed is the number of urls that were processed, skipped ones excluded
+func (r *RunResult) Processed() int {
+	return len(r.Outcomes) - r.Skipped
+}
+
+// AverageDuration is the mean time spent per processed url
+func (r *RunResult) AverageDuration() time.Duration {
+	if r.Processed() == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, outcome := range r.Outcomes {
+		total += outcome.Duration
+	}
+	return total / time.Duration(r.Processed())
+}
+
+// Summary returns a human readable report of the run
+func (r *RunResult) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "processed %d urls in %s (avg %s/url): %d succeeded, %d failed, %d skipped",
+		r.Processed(), r.Duration.Round(time.Millisecond), r.AverageDuration().Round(time.Millisecond), r.Succeeded, r.Failed, r.Skipped)
+	if r.AbortErr != nil {
+		fmt.Fprintf(&b, "\n  aborted: %v", r.AbortErr)
+	}
+
+	classes := make([]string, 0, len(r.ErrorClasses))
+	for class := range r.ErrorClasses {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	for _, class := range classes {
+		fmt.Fprintf(&b, "\n  %s: %d", class, r.ErrorClasses[class])
+	}
+
+	fields := make([]string, 0, len(r.FieldStatuses))
+	for name := range r.FieldStatuses {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	for _, name := range fields {
+		statuses := r.FieldStatuses[name]
+		fmt.Fprintf(&b, "\n  field %s: ok=%d missing=%d error=%d", name,
+			statuses[entity.FieldStatusOK], statuses[entity.FieldStatusMissing], statuses[entity.FieldStatusError])
+
+		matches := make([]string, 0, len(r.SelectorMatches[name]))
+		for matched, count := range r.SelectorMatches[name] {
+			matches = append(matches, fmt.Sprintf("%s=%d", matched, count))
+		}
+		sort.Strings(matches)
+		if len(matches) > 0 {
+			fmt.Fprintf(&b, " matched by %s", strings.Join(matches, " "))
+		}
+	}
+	return b.String()
+}
+
+// errorClass maps an error returned by processUrl to one of the Class constants
+func errorClass(err error) string {
+	switch {
+	case errors.Is(err, scrapper.ErrNavigationTimeout):
+		return ClassNavigationTimeout
+	case errors.Is(err, scrapper.ErrNotFound):
+		return ClassNotFound
+	case errors.Is(err, scrapper.ErrSelectorMissing):
+		return ClassSelectorMissing
+	case errors.Is(err, scrapper.ErrBlocked):
+		return ClassBlocked
+	case errors.Is(err, scrapper.ErrBrowserCrashed):
+		return ClassBrowserCrashed
+	}
+	return ClassOther
+}
@@ -0,0 +1,73 @@
+package scrappermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+	"github.com/indragunawan95/topedcrawler/internal/repo/scrapper"
+)
+
+// scrapeReviews pages through the reviews of a product and stores them. It
+// stops at the first page without a review we have not stored yet, the feed
+// is assumed newest first, or after ReviewPages pages. It returns the number
+// of new reviews.
+func (uc *Usecase) scrapeReviews(ctx context.Context, product entity.Product) (int, error) {
+	if uc.ReviewPages <= 0 {
+		return 0, nil
+	}
+
+	// Product urls may carry tracking parameters, stored reviews are keyed on
+	// the canonical url so a later run finds them
+	productURL := canonicalProductURL(product.Url)
+	known, err := uc.reviewRepo.GetReviewIDs(ctx, productURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stored reviews: %w", err)
+	}
+
+	if err := uc.scrapperRepo.OpenReviewPage(reviewURL(productURL)); err != nil {
+		return 0, fmt.Errorf("failed to open review page: %w", err)
+	}
+
+	created := 0
+	for page := 1; ; page++ {
+		reviews, hasNext, err := uc.scrapperRepo.GetProductReviews()
+		if err != nil {
+			return created, fmt.Errorf("failed to read reviews page %d: %w", page, err)
+		}
+
+		fresh := 0
+		for i := range reviews {
+			reviews[i].ProductID = product.ID
+			reviews[i].ProductUrl = productURL
+			if !known[reviews[i].ExternalID] {
+				known[reviews[i].ExternalID] = true
+				fresh++
+			}
+		}
+		// Known reviews are upserted too, to refresh their helpful count
+		if err := uc.reviewRepo.UpsertReviews(ctx, reviews); err != nil {
+			return created, fmt.Errorf("failed to save reviews: %w", err)
+		}
+		created += fresh
+
+		if fresh == 0 || !hasNext || page >= uc.ReviewPages {
+			return created, nil
+		}
+
+		err = uc.scrapperRepo.NextReviewPage(uc.selectors.Pages["review"].Element("next_page"))
+		if errors.Is(err, scrapper.ErrSelectorMissing) {
+			return created, nil
+		}
+		if err != nil {
+			return created, fmt.Errorf("failed to open reviews page %d: %w", page+1, err)
+		}
+	}
+}
+
+// reviewURL returns the review page of a product, e.g.
+// https://www.tokopedia.com/samsung-official/galaxy-a54/review
+func reviewURL(productURL string) string {
+	return productSubpage(productURL, "review")
+}
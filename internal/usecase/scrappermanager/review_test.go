@@ -0,0 +1,83 @@
+package scrappermanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// fakeReviewRepo stores review IDs per product url
+type fakeReviewRepo struct {
+	ids map[string]map[string]bool
+}
+
+func (r *fakeReviewRepo) GetReviewIDs(ctx context.Context, productUrl string) (map[string]bool, error) {
+	known := make(map[string]bool)
+	for id := range r.ids[productUrl] {
+		known[id] = true
+	}
+	return known, nil
+}
+
+func (r *fakeReviewRepo) UpsertReviews(ctx context.Context, inputs []entity.Review) error {
+	for _, input := range inputs {
+		if r.ids[input.ProductUrl] == nil {
+			r.ids[input.ProductUrl] = make(map[string]bool)
+		}
+		r.ids[input.ProductUrl][input.ExternalID] = true
+	}
+	return nil
+}
+
+// fakeReviewScrapper serves the same first page of reviews, with a next page
+type fakeReviewScrapper struct {
+	ScrapperRepoItf
+	opened    []string
+	nextPages int
+}
+
+func (s *fakeReviewScrapper) OpenReviewPage(url string) error {
+	s.opened = append(s.opened, url)
+	return nil
+}
+
+func (s *fakeReviewScrapper) GetProductReviews() ([]entity.Review, bool, error) {
+	return []entity.Review{{ExternalID: "1"}, {ExternalID: "2"}}, true, nil
+}
+
+func (s *fakeReviewScrapper) NextReviewPage(element entity.FieldSelector) error {
+	s.nextPages++
+	return nil
+}
+
+func TestScrapeReviewsAcrossRunsWithTrackingParams(t *testing.T) {
+	repo := &fakeReviewRepo{ids: make(map[string]map[string]bool)}
+	scrapper := &fakeReviewScrapper{}
+	uc := &Usecase{reviewRepo: repo, scrapperRepo: scrapper, ReviewPages: 1}
+
+	first := entity.Product{Url: "https://www.tokopedia.com/samsung-official/galaxy-a54?extParam=ivf%3Dfalse&src=topads"}
+	created, err := uc.scrapeReviews(context.Background(), first)
+	if err != nil || created != 2 {
+		t.Fatalf("first run = %d, %v, want 2 new reviews", created, err)
+	}
+
+	// The next run sees the product with other tracking parameters
+	uc.ReviewPages = 5
+	second := entity.Product{Url: "https://www.tokopedia.com/samsung-official/galaxy-a54?extParam=whid%3D1"}
+	created, err = uc.scrapeReviews(context.Background(), second)
+	if err != nil || created != 0 {
+		t.Fatalf("second run = %d, %v, want no new reviews", created, err)
+	}
+	if scrapper.nextPages != 0 {
+		t.Errorf("second run read %d more pages, want it to stop at the known first page", scrapper.nextPages)
+	}
+	if _, ok := repo.ids["https://www.tokopedia.com/samsung-official/galaxy-a54"]; !ok || len(repo.ids) != 1 {
+		t.Errorf("reviews stored under %v, want the canonical product url only", repo.ids)
+	}
+	for _, opened := range scrapper.opened {
+		if opened != "https://www.tokopedia.com/samsung-official/galaxy-a54/review" {
+			t.Errorf("opened %s, want the review page without tracking parameters", opened)
+		}
+	}
+}
@@ -2,19 +2,17 @@ package scrappermanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
-	"unicode"
+	"time"
 
 	"github.com/indragunawan95/topedcrawler/internal/entity"
-)
-
-const (
-	BaseURL        = "https://www.tokopedia.com/p/handphone-tablet/handphone?ob=23&page=%d"
-	excludedPrefix = "https://ta.tokopedia.com/"
+	"github.com/indragunawan95/topedcrawler/internal/repo/scrapper"
 )
 
 type ProductRepoItf interface {
@@ -25,6 +23,36 @@ type CSVRepoItf interface {
 	SaveProductsToCSV(ctx context.Context, products []entity.Product) error
 }
 
+type JSONRepoItf interface {
+	SaveProductsToJSON(ctx context.Context, products []entity.Product) error
+}
+
+type ListingRepoItf interface {
+	CreateListingSnapshots(ctx context.Context, inputs []entity.ListingSnapshot) error
+}
+
+type RankRepoItf interface {
+	CreateRankEntries(ctx context.Context, inputs []entity.RankEntry) error
+}
+
+type ShopRepoItf interface {
+	GetShopByDomain(ctx context.Context, domain string) (entity.Shop, bool, error)
+	UpsertShop(ctx context.Context, input entity.Shop) (entity.Shop, error)
+}
+
+type ReviewRepoItf interface {
+	GetReviewIDs(ctx context.Context, productUrl string) (map[string]bool, error)
+	UpsertReviews(ctx context.Context, inputs []entity.Review) error
+}
+
+type CategoryRepoItf interface {
+	UpsertCategoryPath(ctx context.Context, inputs []entity.Category) ([]entity.Category, error)
+}
+
+type DiscussionRepoItf interface {
+	UpsertQuestions(ctx context.Context, inputs []entity.DiscussionQuestion) error
+}
+
 type UrlRepoItf interface {
 	CreateUrls(ctx context.Context, inputs []entity.Url) ([]entity.Url, error)
 	GetUrls(ctx context.Context) ([]entity.Url, error)
@@ -36,237 +64,619 @@ type ScrapperRepoItf interface {
 	OpenPage(url string) error
 	ScrollPage() error
 	ClosePage() error
-	GetProductTitle() (string, error)
-	GetProductDescription() (string, error)
-	GetProductStoreName() (string, error)
-	GetProductPrice() (string, error)
-	GetProductRating() (string, error)
-	GetProductImageLink() (string, error)
-	GetAllProductLinks() ([]string, error)
+	ExtractFields(fields []entity.FieldSelector) []entity.FieldValue
+	ExtractCards(page entity.PageSelectors) ([][]entity.FieldValue, error)
+	GetProductVariants() ([]entity.ProductVariant, string, error)
+	GetProductImages(element entity.FieldSelector) ([]entity.ProductImage, string, error)
+	GetProductSpecs(element entity.FieldSelector) ([]entity.ProductSpec, error)
+	GetProductWholesale() ([]entity.WholesaleTier, string, error)
+	GetProductCategories(element entity.FieldSelector) ([]entity.Category, error)
+	OpenReviewPage(url string) error
+	GetProductReviews() ([]entity.Review, bool, error)
+	NextReviewPage(element entity.FieldSelector) error
+	OpenDiscussionPage(url string) error
+	GetProductDiscussions() ([]entity.DiscussionQuestion, bool, error)
+	NextDiscussionPage(element entity.FieldSelector) error
 }
 
+// ScrapperFactory returns a new scrapper. A scrapper drives one tab at a time,
+// so every worker gets its own.
+type ScrapperFactory func() ScrapperRepoItf
+
 type Usecase struct {
-	scrapperRepo ScrapperRepoItf
-	productRepo  ProductRepoItf
-	urlRepo      UrlRepoItf
-	csvRepo      CSVRepoItf
-	NumWorkers   int
+	scrapperRepo   ScrapperRepoItf
+	newScrapper    ScrapperFactory
+	productRepo    ProductRepoItf
+	urlRepo        UrlRepoItf
+	listingRepo    ListingRepoItf
+	rankRepo       RankRepoItf
+	shopRepo       ShopRepoItf
+	categoryRepo   CategoryRepoItf
+	reviewRepo     ReviewRepoItf
+	discussionRepo DiscussionRepoItf
+	csvRepo        CSVRepoItf
+	jsonRepo       JSONRepoItf
+	selectors      entity.SiteSelectors
+	seeds          entity.SeedSet
+	phoneParser    *phoneParser
+	shopVisits     *shopVisits
+	NumWorkers     int
+	ReviewPages    int // max review pages per product, 0 skips reviews
+	Breaker        BreakerConfig
 }
 
-func New(productRepo ProductRepoItf, urlRepo UrlRepoItf, scrapperRepo ScrapperRepoItf, csvRepo CSVRepoItf, numWorkers int) *Usecase {
+func New(productRepo ProductRepoItf, urlRepo UrlRepoItf, listingRepo ListingRepoItf, rankRepo RankRepoItf, shopRepo ShopRepoItf, categoryRepo CategoryRepoItf, reviewRepo ReviewRepoItf, discussionRepo DiscussionRepoItf, newScrapper ScrapperFactory, csvRepo CSVRepoItf, jsonRepo JSONRepoItf, selectors entity.SiteSelectors, seeds entity.SeedSet, phones entity.PhoneDictionary, numWorkers int, reviewPages int, breaker BreakerConfig) *Usecase {
 
 	return &Usecase{
-		productRepo:  productRepo,
-		urlRepo:      urlRepo,
-		scrapperRepo: scrapperRepo,
-		csvRepo:      csvRepo,
-		NumWorkers:   numWorkers,
+		productRepo:    productRepo,
+		urlRepo:        urlRepo,
+		listingRepo:    listingRepo,
+		rankRepo:       rankRepo,
+		shopRepo:       shopRepo,
+		categoryRepo:   categoryRepo,
+		reviewRepo:     reviewRepo,
+		discussionRepo: discussionRepo,
+		scrapperRepo:   newScrapper(),
+		newScrapper:    newScrapper,
+		csvRepo:        csvRepo,
+		jsonRepo:       jsonRepo,
+		selectors:      selectors,
+		seeds:          seeds,
+		phoneParser:    newPhoneParser(phones),
+		shopVisits:     newShopVisits(),
+		NumWorkers:     numWorkers,
+		ReviewPages:    reviewPages,
+		Breaker:        breaker,
 	}
 }
 
-// Get all seed product link first
+// Get all seed product link first, up to maxLinks per seed. Every card seen
+// on the way is stored as a listing snapshot, and the organic cards as the
+// rank history of this run.
 func (uc *Usecase) GetAllProductLinks(ctx context.Context, maxLinks int) error {
 	if err := uc.scrapperRepo.LaunchTab(); err != nil {
 		return fmt.Errorf("failed to launch tab: %w", err)
 	}
 
-	urls := make([]entity.Url, 0, maxLinks)
+	// Every rank entry of the run shares its start time
+	runAt := time.Now().UTC().Truncate(time.Second)
+	var urls []entity.Url
+	for _, seed := range uc.seeds.Seeds {
+		links, err := uc.getSeedProductLinks(ctx, seed, maxLinks, runAt)
+		if err != nil {
+			return fmt.Errorf("seed %s: %w", seed.Name, err)
+		}
+		for _, link := range links {
+			urls = append(urls, entity.Url{Url: link, Seed: seed.Name})
+		}
+	}
+
+	if _, err := uc.urlRepo.CreateUrls(ctx, urls); err != nil {
+		return fmt.Errorf("failed to save product links: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *Usecase) getSeedProductLinks(ctx context.Context, seed entity.Seed, maxLinks int, runAt time.Time) ([]string, error) {
+	listing := uc.selectors.Pages["listing"]
+	links := make([]string, 0, maxLinks)
 	pageIndex := 1
+	rank := 0
+	organicRank := 0
+	ranked := make(map[string]bool)
+	seen := make(map[string]bool)
 
-	for len(urls) < maxLinks {
-		pageURL := fmt.Sprintf(BaseURL, pageIndex)
+	for len(links) < maxLinks {
+		pageURL := fmt.Sprintf(seed.Url, pageIndex)
 		if err := uc.scrapperRepo.OpenPage(pageURL); err != nil {
-			return fmt.Errorf("failed to open page: %w", err)
+			return nil, fmt.Errorf("failed to open page: %w", err)
 		}
 
 		if err := uc.scrapperRepo.ScrollPage(); err != nil {
-			return fmt.Errorf("failed to scroll page: %w", err)
+			return nil, fmt.Errorf("failed to scroll page: %w", err)
 		}
 
-		links, err := uc.scrapperRepo.GetAllProductLinks()
+		cards, err := uc.scrapperRepo.ExtractCards(listing)
 		if err != nil {
-			return fmt.Errorf("failed to scrape product links: %w", err)
+			return nil, fmt.Errorf("failed to scrape product cards: %w", err)
 		}
 
-		for _, link := range links {
-			if strings.HasPrefix(link, excludedPrefix) {
-				continue // Skip the links with the excluded prefix
+		snapshots := make([]entity.ListingSnapshot, 0, len(cards))
+		for i, values := range cards {
+			snapshot, err := parseListingCard(listing.Fields, values)
+			if err != nil {
+				log.Printf("Skipping card %d on page %d of seed %s: %v", i+1, pageIndex, seed.Name, err)
+				continue
 			}
-			if len(urls) < maxLinks {
-				urls = append(urls, entity.Url{Url: link})
+			rank++
+			snapshot.Seed = seed.Name
+			snapshot.PageIndex = pageIndex
+			snapshot.Position = i + 1
+			snapshot.Rank = rank
+			snapshots = append(snapshots, snapshot)
+		}
+		if err := uc.listingRepo.CreateListingSnapshots(ctx, snapshots); err != nil {
+			return nil, fmt.Errorf("failed to save listing snapshots: %w", err)
+		}
+
+		// Ads are bought placements, only organic cards count towards the rank
+		var entries []entity.RankEntry
+		for _, snapshot := range snapshots {
+			url := canonicalProductURL(snapshot.Url)
+			if snapshot.IsAd || ranked[url] {
+				continue
+			}
+			ranked[url] = true
+			organicRank++
+			entries = append(entries, entity.RankEntry{
+				Seed:      seed.Name,
+				Url:       url,
+				RunAt:     runAt,
+				Rank:      organicRank,
+				PageIndex: snapshot.PageIndex,
+				Position:  snapshot.Position,
+			})
+		}
+		if err := uc.rankRepo.CreateRankEntries(ctx, entries); err != nil {
+			return nil, fmt.Errorf("failed to save rank history: %w", err)
+		}
+
+		// An advertised product often also has an organic card, scrape it once.
+		// Unresolved ad cards have no product to scrape. The canonical url is
+		// stored so products line up with the rank history across runs.
+		found := len(links)
+		for _, snapshot := range snapshots {
+			url := canonicalProductURL(snapshot.Url)
+			if url == "" || seen[url] {
+				continue
+			}
+			seen[url] = true
+			if len(links) < maxLinks {
+				links = append(links, url)
 			} else {
 				break // We have reached the maxLinks limit
 			}
 		}
+		// A page repeating products already seen means the listing ran out
+		if len(links) == found {
+			break
+		}
 
 		pageIndex++ // Move to the next page
 	}
 
-	if _, err := uc.urlRepo.CreateUrls(ctx, urls); err != nil {
-		return fmt.Errorf("failed to save product links: %w", err)
-	}
-
-	return nil
+	return links, nil
 }
 
-// Scrap product detail from seed product link
-func (uc *Usecase) ProductDetailsScrapper() error {
-	urls, err := uc.urlRepo.GetUrls(context.Background())
+// Scrap product detail from seed product link. Cancelling ctx stops
+// dispatching, urls not dispatched yet are reported as skipped.
+func (uc *Usecase) ProductDetailsScrapper(ctx context.Context) (*RunResult, error) {
+	urls, err := uc.urlRepo.GetUrls(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get URLs: %w", err)
+		return nil, fmt.Errorf("failed to get URLs: %w", err)
 	}
 
+	start := time.Now()
+	breaker := newCircuitBreaker(uc.Breaker)
 	// Create a channel to send URLs to be processed.
 	urlsChan := make(chan entity.Url)
-	// Create a channel to communicate errors from goroutines.
-	errChan := make(chan error, uc.NumWorkers)
+	// Buffered so workers never block while the results are being collected.
+	// Every url gets exactly one outcome.
+	outcomesChan := make(chan UrlOutcome, len(urls))
 	// WaitGroup to wait for all goroutines to finish.
 	var wg sync.WaitGroup
+	// inFlight counts the dispatched urls whose outcome is not reported yet.
+	// Only the dispatcher adds and waits, workers mark their urls done.
+	var inFlight sync.WaitGroup
 
 	// Start the specified number of worker goroutines.
 	for i := 0; i < uc.NumWorkers; i++ {
 		wg.Add(1)
-		go worker(&wg, urlsChan, errChan, uc)
+		go worker(&wg, &inFlight, urlsChan, outcomesChan, breaker, uc)
 	}
 
 	// Send URLs to the channel for the workers to process.
+	// The dispatcher owns the breaker: once it trips, dispatching pauses until
+	// the urls in flight are done, then a single canary url decides whether
+	// to resume or abort the job.
+	var abortErr error
+	wg.Add(1)
 	go func() {
-		for _, urlEntity := range urls {
-			urlsChan <- urlEntity
+		defer wg.Done()
+		defer close(urlsChan) // Close the channel to signal workers to stop.
+
+		abort := func(err error, skipped []entity.Url) {
+			abortErr = err
+			log.Printf("Aborting run: %v", abortErr)
+			for _, url := range skipped {
+				outcomesChan <- UrlOutcome{Url: url, Err: ErrSkipped, Class: ClassSkipped}
+			}
+		}
+
+		dispatcher := uc.withOwnScrapper()
+		for i := 0; i < len(urls); i++ {
+			tripped, reason := breaker.state()
+			if !tripped {
+				inFlight.Add(1)
+				select {
+				case urlsChan <- urls[i]:
+				case <-ctx.Done():
+					inFlight.Done()
+					abort(fmt.Errorf("run cancelled: %w", ctx.Err()), urls[i:])
+					return
+				}
+				continue
+			}
+
+			inFlight.Wait()
+			log.Printf("Circuit breaker tripped after %s, pausing for %s before probing", reason, uc.Breaker.Cooldown)
+			cooldown := time.NewTimer(uc.Breaker.Cooldown)
+			select {
+			case <-cooldown.C:
+			case <-ctx.Done():
+				cooldown.Stop()
+				abort(fmt.Errorf("run cancelled: %w", ctx.Err()), urls[i:])
+				return
+			}
+
+			canary := dispatcher.processOne(urls[i])
+			outcomesChan <- canary
+			if canary.Err != nil {
+				abort(fmt.Errorf("%w: %s, canary %s failed: %v", ErrCircuitOpen, reason, canary.Url.Url, canary.Err), urls[i+1:])
+				return
+			}
+			log.Printf("Canary %s succeeded, resuming", canary.Url.Url)
+			breaker.reset()
 		}
-		close(urlsChan) // Close the channel to signal workers to stop.
 	}()
 
-	// Wait for all goroutines to complete and close the error channel.
+	// Wait for all goroutines to complete and close the outcome channel.
 	go func() {
 		wg.Wait()
-		close(errChan)
+		close(outcomesChan)
 	}()
 
-	// Collect errors, if any.
-	var scrappingErrors []error
-	for e := range errChan {
-		if e != nil {
-			scrappingErrors = append(scrappingErrors, e)
-		}
+	result := newRunResult()
+	for outcome := range outcomesChan {
+		result.add(outcome)
 	}
+	result.AbortErr = abortErr
+	result.Duration = time.Since(start)
 
-	if len(scrappingErrors) > 0 {
-		// Handle errors accordingly. For example, you could log them or retry failed operations.
-		// For now, just returning the first error.
-		return scrappingErrors[0]
-	}
-
-	return nil
+	return result, nil
 }
 
-// Worker function that processes URLs from the urlsChan and sends errors to errChan.
-func worker(wg *sync.WaitGroup, urlsChan <-chan entity.Url, errChan chan<- error, uc *Usecase) {
+// Worker function that processes URLs from the urlsChan and reports each outcome to outcomesChan.
+func worker(wg, inFlight *sync.WaitGroup, urlsChan <-chan entity.Url, outcomesChan chan<- UrlOutcome, breaker *circuitBreaker, uc *Usecase) {
 	defer wg.Done()
+	uc = uc.withOwnScrapper()
 	for url := range urlsChan {
-		if err := uc.processUrl(url); err != nil {
-			errChan <- err
-			// For now, let's just log the error and move on to the next URL.
-			log.Printf("Error processing URL %s: %v", url.Url, err)
-		}
+		outcome := uc.processOne(url)
+		breaker.record(outcome)
+		outcomesChan <- outcome
+		inFlight.Done()
+	}
+}
+
+// withOwnScrapper returns a copy of the usecase driving its own scrapper, so
+// concurrent workers never share a tab or its captured responses
+func (uc *Usecase) withOwnScrapper() *Usecase {
+	own := *uc
+	own.scrapperRepo = uc.newScrapper()
+	return &own
+}
+
+// processOne processes a single url and times it
+func (uc *Usecase) processOne(url entity.Url) UrlOutcome {
+	start := time.Now()
+	product, err := uc.processUrl(url)
+	outcome := UrlOutcome{
+		Url:      url,
+		Err:      err,
+		Fields:   product.Extraction,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		outcome.Class = errorClass(err)
+		log.Printf("Error processing URL %s: %v", url.Url, err)
 	}
+	return outcome
 }
 
-func (uc *Usecase) processUrl(url entity.Url) error {
+func (uc *Usecase) processUrl(url entity.Url) (entity.Product, error) {
 	if err := uc.scrapperRepo.LaunchTab(); err != nil {
-		return fmt.Errorf("failed to launch tab: %w", err)
+		return entity.Product{}, fmt.Errorf("failed to launch tab: %w", err)
 	}
 	defer uc.scrapperRepo.ClosePage()
 
 	if err := uc.scrapperRepo.OpenPage(url.Url); err != nil {
-		return fmt.Errorf("failed to open product detail page: %w", err)
+		return entity.Product{}, fmt.Errorf("failed to open product detail page: %w", err)
 	}
 
 	if err := uc.scrapperRepo.ScrollPage(); err != nil {
-		return fmt.Errorf("failed to scroll page: %w", err)
+		return entity.Product{}, fmt.Errorf("failed to scroll page: %w", err)
 	}
 
+	// Snapshots of a product are grouped by url, urls stored before links
+	// were canonical still carry tracking parameters
 	product, err := uc.scrapeProductDetails()
+	product.Url = canonicalProductURL(url.Url)
+	if err != nil {
+		return product, fmt.Errorf("failed to scrape product details: %w", err)
+	}
+
+	// Phone attributes are parsed from the title and specs, for phones only
+	product.Phone = uc.enrichPhone(product, url.Seed)
+
+	shop, err := uc.saveShop(context.Background(), url.Url, product.Shop)
 	if err != nil {
-		return fmt.Errorf("failed to scrape product details: %w", err)
+		return product, fmt.Errorf("failed to save shop: %w", err)
+	}
+	product.Shop = shop
+	product.ShopID = shop.ID
+
+	if len(product.Categories) > 0 {
+		categories, err := uc.categoryRepo.UpsertCategoryPath(context.Background(), product.Categories)
+		if err != nil {
+			return product, fmt.Errorf("failed to save categories: %w", err)
+		}
+		product.Categories = categories
+		product.CategoryID = categories[len(categories)-1].ID
 	}
 
-	_, err = uc.productRepo.CreateProduct(context.Background(), product)
+	created, err := uc.productRepo.CreateProduct(context.Background(), product)
 	if err != nil {
-		return fmt.Errorf("failed to create product: %w", err)
+		return product, fmt.Errorf("failed to create product: %w", err)
 	}
+	product.ID = created.ID
 
 	err = uc.urlRepo.MarkUrlAsScrapped(context.Background(), url.ID)
 	if err != nil {
-		return fmt.Errorf("failed to update scrapped: %w", err)
+		return product, fmt.Errorf("failed to update scrapped: %w", err)
 	}
 
 	// append the product details to the CSV file.
 	err = uc.csvRepo.SaveProductsToCSV(context.Background(), []entity.Product{product})
 	if err != nil {
-		return fmt.Errorf("failed to save product to CSV: %w", err)
+		return product, fmt.Errorf("failed to save product to CSV: %w", err)
+	}
+
+	err = uc.jsonRepo.SaveProductsToJSON(context.Background(), []entity.Product{product})
+	if err != nil {
+		return product, fmt.Errorf("failed to save product to JSON: %w", err)
+	}
+
+	// Reviews are best effort, the product is stored already
+	reviews, err := uc.scrapeReviews(context.Background(), product)
+	if err != nil {
+		log.Printf("Error scraping reviews of %s: %v", url.Url, err)
+	} else if reviews > 0 {
+		log.Printf("Stored %d new reviews of %s", reviews, url.Url)
+	}
+
+	// Discussions are opt-in per seed and best effort like reviews
+	questions, err := uc.scrapeDiscussions(context.Background(), product, url.Seed)
+	if err != nil {
+		log.Printf("Error scraping discussions of %s: %v", url.Url, err)
+	} else if questions > 0 {
+		log.Printf("Stored %d discussion questions of %s", questions, url.Url)
 	}
 
 	log.Printf("Processed product: %s\n", product.Name)
-	return nil
+	return product, nil
 }
 
-func (uc *Usecase) scrapeProductDetails() (entity.Product, error) {
-	var product entity.Product
+// productSetters copies an extracted field value onto the product, keyed by
+// the field name used in the selector file. The source tells structured
+// values from text shown on the page.
+var productSetters = map[string]func(product *entity.Product, value, source string) error{
+	"name": func(product *entity.Product, value, source string) error {
+		product.Name = value
+		return nil
+	},
+	"description": func(product *entity.Product, value, source string) error {
+		product.Description = value
+		return nil
+	},
+	"description_html": func(product *entity.Product, value, source string) error {
+		product.DescriptionHTML = value
+		return nil
+	},
+	"price": func(product *entity.Product, value, source string) error {
+		price, err := entity.ParseIDR(value, isStructured(source))
+		if err != nil {
+			return err
+		}
+		product.Price = price
+		return nil
+	},
+	"original_price": func(product *entity.Product, value, source string) error {
+		price, err := entity.ParseIDR(value, isStructured(source))
+		if err != nil {
+			return err
+		}
+		product.OriginalPrice = price
+		return nil
+	},
+	"discount_percent": func(product *entity.Product, value, source string) error {
+		discount, err := parsePercent(value)
+		if err != nil {
+			return err
+		}
+		product.DiscountPercent = discount
+		return nil
+	},
+	"rating": func(product *entity.Product, value, source string) error {
+		ratingFloat, err := strconv.ParseFloat(value, 32) // 32 specifies the precision
+		if err != nil {
+			return fmt.Errorf("error converting string to float: %w", err)
+		}
+		product.Rating = float32(ratingFloat)
+		return nil
+	},
+	"image_link": func(product *entity.Product, value, source string) error {
+		product.ImageLink = value
+		return nil
+	},
+	"sku": func(product *entity.Product, value, source string) error {
+		product.SKU = value
+		return nil
+	},
+	"review_count": func(product *entity.Product, value, source string) error {
+		product.ReviewCountText = value
+		count, err := parseIndonesianCount(value)
+		if err != nil {
+			return err
+		}
+		product.ReviewCount = count
+		return nil
+	},
+	"sold_count": func(product *entity.Product, value, source string) error {
+		product.SoldCountText = value
+		count, err := parseIndonesianCount(value)
+		if err != nil {
+			return err
+		}
+		product.SoldCount = count
+		return nil
+	},
+	"availability": func(product *entity.Product, value, source string) error {
+		availability, ok := schemaAvailability[strings.ToLower(value)]
+		if !ok {
+			return fmt.Errorf("unknown availability %q", value)
+		}
+		product.Availability = availability
+		return nil
+	},
+	"stock": func(product *entity.Product, value, source string) error {
+		product.StockText = value
+		stock, err := parseStock(value)
+		if err != nil {
+			return err
+		}
+		product.Stock = stock
+		return nil
+	},
+	"min_order": func(product *entity.Product, value, source string) error {
+		minOrder, err := parseIndonesianCount(value)
+		if err != nil {
+			return err
+		}
+		product.MinOrder = minOrder
+		return nil
+	},
+	"pre_order": func(product *entity.Product, value, source string) error {
+		// Structured data gives a boolean, the page a label like "Pre Order 7 Hari"
+		switch strings.ToLower(value) {
+		case "false":
+			product.IsPreOrder = false
+		case "true":
+			product.IsPreOrder = true
+		default:
+			product.IsPreOrder = true
+			product.PreOrderText = value
+		}
+		return nil
+	},
+}
 
-	title, err := uc.scrapperRepo.GetProductTitle()
-	if err != nil {
-		return product, fmt.Errorf("failed to get product title: %w", err)
+// scrapeProductDetails extracts every product field and records its status.
+// Only a failing required field returns an error, optional fields are marked
+// missing or error and left empty.
+func (uc *Usecase) scrapeProductDetails() (entity.Product, error) {
+	product := entity.Product{
+		Extraction: make(entity.FieldExtractions),
 	}
 
-	description, err := uc.scrapperRepo.GetProductDescription()
-	if err != nil {
-		return product, fmt.Errorf("failed to get product description: %w", err)
+	pdp := uc.selectors.Pages["pdp"]
+	values := uc.scrapperRepo.ExtractFields(pdp.Fields)
+	for i, field := range pdp.Fields {
+		err := values[i].Err
+		if set, ok := productSetters[field.Name]; ok && err == nil {
+			err = set(&product, values[i].Value, values[i].Source)
+		}
+		if set, ok := promoSetters[field.Name]; ok && err == nil {
+			err = set(&product, values[i].Value, values[i].Source)
+		}
+		if set, ok := shopSetters[field.Name]; ok && err == nil {
+			err = set(&product.Shop, values[i].Value)
+		}
+
+		if err != nil {
+			product.Extraction[field.Name] = entity.FieldExtraction{Status: failedStatus(err), Source: values[i].Source, Matched: values[i].Matched, Error: err.Error()}
+			if field.Required {
+				return product, fmt.Errorf("failed to get product %s: %w", field.Name, err)
+			}
+			continue
+		}
+		product.Extraction[field.Name] = entity.FieldExtraction{Status: entity.FieldStatusOK, Source: values[i].Source, Matched: values[i].Matched}
+		if values[i].MatchIndex > 0 {
+			log.Printf("Field %s matched fallback selector %s", field.Name, values[i].Matched)
+		}
 	}
 
-	storeName, err := uc.scrapperRepo.GetProductStoreName()
-	if err != nil {
-		return product, fmt.Errorf("failed to get product storename: %w", err)
+	// The discount badge is sometimes missing while the struck-through price is shown
+	if product.DiscountPercent == 0 && product.OriginalPrice.Amount > product.Price.Amount {
+		discount := float64(product.OriginalPrice.Amount-product.Price.Amount) / float64(product.OriginalPrice.Amount) * 100
+		product.DiscountPercent = float32(math.Round(discount*100) / 100)
 	}
 
-	price, err := uc.scrapperRepo.GetProductPrice()
+	resolveAvailability(&product)
+
+	// Variants are optional, most products have none
+	variants, source, err := uc.scrapperRepo.GetProductVariants()
 	if err != nil {
-		return product, fmt.Errorf("failed to get product price: %w", err)
+		product.Extraction["variants"] = entity.FieldExtraction{Status: failedStatus(err), Error: err.Error()}
+	} else {
+		product.Variants = variants
+		product.Extraction["variants"] = entity.FieldExtraction{Status: entity.FieldStatusOK, Source: source}
 	}
 
-	rating, err := uc.scrapperRepo.GetProductRating()
+	images, source, err := uc.scrapperRepo.GetProductImages(pdp.Element("images"))
 	if err != nil {
-		return product, fmt.Errorf("failed to get product rating: %w", err)
+		product.Extraction["images"] = entity.FieldExtraction{Status: failedStatus(err), Error: err.Error()}
+	} else {
+		product.Images = images
+		product.Extraction["images"] = entity.FieldExtraction{Status: entity.FieldStatusOK, Source: source}
+		if product.ImageLink == "" {
+			product.ImageLink = images[0].Url
+		}
 	}
 
-	ratingFloat, err := strconv.ParseFloat(rating, 32) // 32 specifies the precision
+	specs, err := uc.scrapperRepo.GetProductSpecs(pdp.Element("specs"))
 	if err != nil {
-		return product, fmt.Errorf("error converting string to float: %w", err)
+		product.Extraction["specs"] = entity.FieldExtraction{Status: failedStatus(err), Error: err.Error()}
+	} else {
+		product.Specs = normaliseSpecs(specs)
+		product.Extraction["specs"] = entity.FieldExtraction{Status: entity.FieldStatusOK, Source: scrapper.SourceDOM}
 	}
+	product.Specs = mergeSpecHints(product.Specs, normaliseSpecs(descriptionSpecHints(product.Description)))
 
-	imageLink, err := uc.scrapperRepo.GetProductImageLink()
+	// Wholesale prices are optional, most products have none
+	wholesale, source, err := uc.scrapperRepo.GetProductWholesale()
 	if err != nil {
-		return product, fmt.Errorf("failed to get product main image url: %w", err)
+		product.Extraction["wholesale"] = entity.FieldExtraction{Status: failedStatus(err), Error: err.Error()}
+	} else {
+		product.Wholesale = wholesale
+		product.Extraction["wholesale"] = entity.FieldExtraction{Status: entity.FieldStatusOK, Source: source}
 	}
 
-	product = entity.Product{
-		Name:        title,
-		Description: description,
-		StoreName:   storeName,
-		Price:       extractPrice(price),
-		Rating:      float32(ratingFloat),
-		ImageLink:   imageLink,
+	categories, err := uc.scrapperRepo.GetProductCategories(pdp.Element("breadcrumb"))
+	if err != nil {
+		product.Extraction["categories"] = entity.FieldExtraction{Status: failedStatus(err), Error: err.Error()}
+	} else {
+		product.Categories = categories
+		product.Extraction["categories"] = entity.FieldExtraction{Status: entity.FieldStatusOK}
 	}
 
 	return product, nil
 }
 
-func extractPrice(priceWithCurrency string) string {
-	// Remove all non-digit characters
-	return strings.Map(func(r rune) rune {
-		if unicode.IsDigit(r) {
-			return r
-		}
-		return -1
-	}, priceWithCurrency)
+// failedStatus tells a field that is absent from the page from one that failed to extract
+func failedStatus(err error) entity.FieldStatus {
+	if errors.Is(err, scrapper.ErrSelectorMissing) {
+		return entity.FieldStatusMissing
+	}
+	return entity.FieldStatusError
 }
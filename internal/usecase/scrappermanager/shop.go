@@ -0,0 +1,242 @@
+package scrappermanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+const shopBaseURL = "https://www.tokopedia.com/%s"
+
+// shopSetters copies an extracted field value onto the shop. The same field
+// names are used on the product page footer and on the shop page.
+var shopSetters = map[string]func(shop *entity.Shop, value string) error{
+	"shop_id": func(shop *entity.Shop, value string) error {
+		shop.ExternalID = value
+		return nil
+	},
+	"shop_name": func(shop *entity.Shop, value string) error {
+		shop.Name = value
+		return nil
+	},
+	"shop_city": func(shop *entity.Shop, value string) error {
+		shop.City = value
+		return nil
+	},
+	"shop_badge": func(shop *entity.Shop, value string) error {
+		shop.Badge = parseShopBadge(value)
+		return nil
+	},
+	"shop_rating": func(shop *entity.Shop, value string) error {
+		rating, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 32)
+		if err != nil {
+			return fmt.Errorf("error converting shop rating: %w", err)
+		}
+		shop.Rating = float32(rating)
+		return nil
+	},
+	"shop_response_time": func(shop *entity.Shop, value string) error {
+		shop.ResponseTime = value
+		return nil
+	},
+	"shop_join_date": func(shop *entity.Shop, value string) error {
+		joinedAt, err := parseJoinDate(value)
+		if err != nil {
+			return err
+		}
+		shop.JoinedAt = &joinedAt
+		return nil
+	},
+}
+
+// saveShop fills in the shop of a product from its url, visits the shop page
+// for the metadata the product page does not show, and stores it
+func (uc *Usecase) saveShop(ctx context.Context, productURL string, shop entity.Shop) (entity.Shop, error) {
+	shop.Domain = shopDomain(productURL)
+	if shop.Domain == "" {
+		return entity.Shop{}, fmt.Errorf("no shop domain in url %s", productURL)
+	}
+
+	stored, found, err := uc.shopRepo.GetShopByDomain(ctx, shop.Domain)
+	if err != nil {
+		return entity.Shop{}, fmt.Errorf("failed to get shop: %w", err)
+	}
+	if found {
+		shop = mergeShop(stored, shop)
+	}
+
+	// The shop page costs a page load, only visit it once per shop. Workers
+	// read the same unscraped shop concurrently, the first to claim it visits.
+	if shopPage, ok := uc.selectors.Pages["shop"]; ok && shop.ScrapedAt == nil && uc.shopVisits.claim(shop.Domain) {
+		if err := uc.scrapeShopPage(&shop, shopPage); err != nil {
+			log.Printf("Error scraping shop page of %s: %v", shop.Domain, err)
+		}
+	}
+
+	if shop.Name == "" {
+		shop.Name = shop.Domain
+	}
+	if shop.Badge == "" {
+		shop.Badge = entity.ShopBadgeNone
+	}
+	return uc.shopRepo.UpsertShop(ctx, shop)
+}
+
+// shopVisits is the set of shops whose page was visited this run, shared by
+// all workers
+type shopVisits struct {
+	mu      sync.Mutex
+	domains map[string]bool
+}
+
+func newShopVisits() *shopVisits {
+	return &shopVisits{domains: make(map[string]bool)}
+}
+
+// claim reports whether the shop was not visited yet and marks it visited
+func (v *shopVisits) claim(domain string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.domains[domain] {
+		return false
+	}
+	v.domains[domain] = true
+	return true
+}
+
+// scrapeShopPage reads the shop page in a tab of its own, the product page
+// is still read after the shop is saved
+func (uc *Usecase) scrapeShopPage(shop *entity.Shop, page entity.PageSelectors) error {
+	scrapper := uc.newScrapper()
+	if err := scrapper.LaunchTab(); err != nil {
+		return fmt.Errorf("failed to launch tab: %w", err)
+	}
+	defer scrapper.ClosePage()
+
+	if err := scrapper.OpenPage(fmt.Sprintf(shopBaseURL, shop.Domain)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	scraped := entity.Shop{ScrapedAt: &now}
+	values := scrapper.ExtractFields(page.Fields)
+	for _, value := range values {
+		set, ok := shopSetters[value.Name]
+		if !ok || value.Err != nil {
+			continue
+		}
+		if err := set(&scraped, value.Value); err != nil {
+			log.Printf("Error reading shop %s %s: %v", shop.Domain, value.Name, err)
+		}
+	}
+	*shop = mergeShop(*shop, scraped)
+	return nil
+}
+
+// mergeShop overlays the non-empty fields of update on base
+func mergeShop(base, update entity.Shop) entity.Shop {
+	if update.ExternalID != "" {
+		base.ExternalID = update.ExternalID
+	}
+	if update.Domain != "" {
+		base.Domain = update.Domain
+	}
+	if update.Name != "" {
+		base.Name = update.Name
+	}
+	if update.City != "" {
+		base.City = update.City
+	}
+	if update.Badge != "" && update.Badge != entity.ShopBadgeNone {
+		base.Badge = update.Badge
+	}
+	if update.Rating != 0 {
+		base.Rating = update.Rating
+	}
+	if update.ResponseTime != "" {
+		base.ResponseTime = update.ResponseTime
+	}
+	if update.JoinedAt != nil {
+		base.JoinedAt = update.JoinedAt
+	}
+	if update.ScrapedAt != nil {
+		base.ScrapedAt = update.ScrapedAt
+	}
+	return base
+}
+
+// shopDomain returns the first path segment of a product url, e.g.
+// "samsung-official" for https://www.tokopedia.com/samsung-official/galaxy-a54
+func shopDomain(productURL string) string {
+	parsed, err := url.Parse(productURL)
+	if err != nil {
+		return ""
+	}
+	domain, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+	return domain
+}
+
+// shopBadges are the badge labels and image alt texts, lowercased with
+// separators collapsed to a space
+var shopBadges = map[string]entity.ShopBadge{
+	"official store":     entity.ShopBadgeOfficialStore,
+	"official":           entity.ShopBadgeOfficialStore,
+	"os":                 entity.ShopBadgeOfficialStore,
+	"power merchant pro": entity.ShopBadgePowerMerchantPro,
+	"power shop":         entity.ShopBadgePowerMerchantPro,
+	"pm pro":             entity.ShopBadgePowerMerchantPro,
+	"power merchant":     entity.ShopBadgePowerMerchant,
+	"pm":                 entity.ShopBadgePowerMerchant,
+}
+
+var badgeSeparators = regexp.MustCompile(`[\s_-]+`)
+
+// parseShopBadge maps badge labels or image alt texts to a ShopBadge, e.g.
+// "Power Merchant PRO" or "power_merchant", other texts give ShopBadgeNone
+func parseShopBadge(value string) entity.ShopBadge {
+	value = strings.TrimSpace(badgeSeparators.ReplaceAllString(strings.ToLower(value), " "))
+	if badge, ok := shopBadges[value]; ok {
+		return badge
+	}
+	return entity.ShopBadgeNone
+}
+
+var indonesianMonths = map[string]time.Month{
+	"jan": time.January, "januari": time.January,
+	"feb": time.February, "februari": time.February,
+	"mar": time.March, "maret": time.March,
+	"apr": time.April, "april": time.April,
+	"mei": time.May,
+	"jun": time.June, "juni": time.June,
+	"jul": time.July, "juli": time.July,
+	"agu": time.August, "agt": time.August, "agustus": time.August,
+	"sep": time.September, "september": time.September,
+	"okt": time.October, "oktober": time.October,
+	"nov": time.November, "november": time.November,
+	"des": time.December, "desember": time.December,
+}
+
+var joinDatePattern = regexp.MustCompile(`(?i)(?:([a-z]+)\s+)?(\d{4})`)
+
+// parseJoinDate parses "Bergabung sejak Januari 2019", "Buka sejak Jan 2019"
+// or "2019" into the first day of that month
+func parseJoinDate(value string) (time.Time, error) {
+	for _, match := range joinDatePattern.FindAllStringSubmatch(value, -1) {
+		year, _ := strconv.Atoi(match[2])
+		month, ok := indonesianMonths[strings.ToLower(match[1])]
+		if !ok {
+			month = time.January
+		}
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("no join date in %q", value)
+}
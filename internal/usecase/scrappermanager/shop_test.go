@@ -0,0 +1,139 @@
+package scrappermanager
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestParseShopBadge(t *testing.T) {
+	tests := []struct {
+		value string
+		want  entity.ShopBadge
+	}{
+		{"Official Store", entity.ShopBadgeOfficialStore},
+		{"Power Merchant PRO", entity.ShopBadgePowerMerchantPro},
+		{"power_merchant_pro", entity.ShopBadgePowerMerchantPro},
+		{"Power Merchant", entity.ShopBadgePowerMerchant},
+		{"power_merchant", entity.ShopBadgePowerMerchant},
+		{"Produk Terlaris", entity.ShopBadgeNone},
+		{"Promo", entity.ShopBadgeNone},
+		{"", entity.ShopBadgeNone},
+	}
+	for _, tt := range tests {
+		if got := parseShopBadge(tt.value); got != tt.want {
+			t.Errorf("parseShopBadge(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseJoinDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"Bergabung sejak Januari 2019", time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC), false},
+		{"Buka sejak Agt 2021", time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2018", time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC), false},
+		{"Bergabung sejak lama", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseJoinDate(tt.value)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("parseJoinDate(%q) = %v, %v, want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestShopDomain(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.tokopedia.com/samsung-official/galaxy-a54", "samsung-official"},
+		{"https://www.tokopedia.com/samsung-official", "samsung-official"},
+		{"https://www.tokopedia.com/", ""},
+	}
+	for _, tt := range tests {
+		if got := shopDomain(tt.url); got != tt.want {
+			t.Errorf("shopDomain(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestMergeShopKeepsScrapedAt(t *testing.T) {
+	scrapedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	stored := entity.Shop{Domain: "samsung-official", Badge: entity.ShopBadgeOfficialStore, ScrapedAt: &scrapedAt}
+
+	// A product page shows no badge and was never a shop page visit
+	got := mergeShop(stored, entity.Shop{Name: "Samsung Official", Badge: entity.ShopBadgeNone})
+	if got.ScrapedAt == nil || !got.ScrapedAt.Equal(scrapedAt) {
+		t.Errorf("mergeShop ScrapedAt = %v, want %v", got.ScrapedAt, scrapedAt)
+	}
+	if got.Badge != entity.ShopBadgeOfficialStore || got.Name != "Samsung Official" {
+		t.Errorf("mergeShop = %+v", got)
+	}
+}
+
+// fakeShopRepo never has the shop stored yet
+type fakeShopRepo struct{}
+
+func (r *fakeShopRepo) GetShopByDomain(ctx context.Context, domain string) (entity.Shop, bool, error) {
+	return entity.Shop{}, false, nil
+}
+
+func (r *fakeShopRepo) UpsertShop(ctx context.Context, input entity.Shop) (entity.Shop, error) {
+	return input, nil
+}
+
+// shopPageScrapper counts the shop pages opened by all its tabs
+type shopPageScrapper struct {
+	ScrapperRepoItf
+	opened *int32
+}
+
+func (s *shopPageScrapper) LaunchTab() error { return nil }
+
+func (s *shopPageScrapper) ClosePage() error { return nil }
+
+func (s *shopPageScrapper) OpenPage(url string) error {
+	atomic.AddInt32(s.opened, 1)
+	// Stay on the page long enough for the other workers to read the shop
+	time.Sleep(10 * time.Millisecond)
+	return nil
+}
+
+func (s *shopPageScrapper) ExtractFields(fields []entity.FieldSelector) []entity.FieldValue {
+	return nil
+}
+
+func TestSaveShopVisitsShopPageOncePerRun(t *testing.T) {
+	var opened int32
+	uc := &Usecase{
+		shopRepo:    &fakeShopRepo{},
+		newScrapper: func() ScrapperRepoItf { return &shopPageScrapper{opened: &opened} },
+		selectors:   entity.SiteSelectors{Pages: map[string]entity.PageSelectors{"shop": {}}},
+		shopVisits:  newShopVisits(),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(worker *Usecase) {
+			defer wg.Done()
+			if _, err := worker.saveShop(context.Background(), "https://www.tokopedia.com/samsung-official/galaxy-a54", entity.Shop{}); err != nil {
+				t.Errorf("saveShop: %v", err)
+			}
+		}(uc.withOwnScrapper())
+	}
+	wg.Wait()
+
+	if opened != 1 {
+		t.Errorf("shop page opened %d times, want once", opened)
+	}
+}
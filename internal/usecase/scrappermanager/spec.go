@@ -0,0 +1,140 @@
+package scrappermanager
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// specKeys maps the Indonesian labels of the detail list to normalised keys
+var specKeys = map[string]string{
+	"kondisi":        "condition",
+	"berat":          "weight",
+	"berat satuan":   "weight",
+	"kategori":       "category",
+	"etalase":        "showcase",
+	"min. pemesanan": "min_order",
+	"min pemesanan":  "min_order",
+	"merek":          "brand",
+	"garansi":        "warranty",
+}
+
+var (
+	// specHintPattern matches "Key: value" lines of a description, e.g.
+	// "RAM: 8GB" or "- Baterai : 5000 mAh"
+	specHintPattern = regexp.MustCompile(`^(?:[-•*]\s*)?(\p{L}[\p{L}\d /&().+-]{0,39}?)\s*[:=]\s*(\S.{0,199})$`)
+	// A clock time split on its colon, e.g. "Buka jam 08:00 - 17:00", is not a hint
+	clockValue    = regexp.MustCompile(`^\d+:`)
+	nonKeyChars   = regexp.MustCompile(`[^a-z0-9]+`)
+	weightPattern = regexp.MustCompile(`(?i)^(\d+(?:[.,]\d+)*)\s*(g|gr|gram|kg|kilogram)\b`)
+	leadingNumber = regexp.MustCompile(`^(\d+(?:[.,]\d+)*)`)
+)
+
+// normaliseSpecs fills in the normalised key and typed value of each spec
+func normaliseSpecs(specs []entity.ProductSpec) []entity.ProductSpec {
+	out := make([]entity.ProductSpec, 0, len(specs))
+	for _, spec := range specs {
+		spec.Key = specKey(spec.Label)
+		spec.Value = spec.RawValue
+
+		switch spec.Key {
+		case "condition":
+			spec.Value = parseCondition(spec.RawValue)
+		case "weight":
+			if grams, ok := parseWeightGrams(spec.RawValue); ok {
+				spec.Number = &grams
+				spec.Unit = "g"
+			}
+		case "min_order":
+			if match := leadingNumber.FindString(spec.RawValue); match != "" {
+				if value, err := parseIndonesianNumber(match); err == nil {
+					spec.Number = &value
+					spec.Unit = "pcs"
+				}
+			}
+		}
+		out = append(out, spec)
+	}
+	return out
+}
+
+// descriptionSpecHints returns the "Key: value" lines of a description as specs
+func descriptionSpecHints(description string) []entity.ProductSpec {
+	var hints []entity.ProductSpec
+	for _, line := range strings.Split(description, "\n") {
+		match := specHintPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		label := strings.TrimSpace(match[1])
+		value := strings.TrimSpace(match[2])
+		// Links split on their scheme, e.g. "Info https://..."
+		if strings.HasPrefix(value, "//") || endsWithDigit(label) || clockValue.MatchString(value) {
+			continue
+		}
+		hints = append(hints, entity.ProductSpec{
+			Label:    label,
+			RawValue: value,
+			Source:   entity.SpecSourceDescription,
+		})
+	}
+	return hints
+}
+
+func endsWithDigit(label string) bool {
+	return label != "" && label[len(label)-1] >= '0' && label[len(label)-1] <= '9'
+}
+
+// mergeSpecHints adds the hints whose key the detail list does not have,
+// the first hint of a key wins
+func mergeSpecHints(specs, hints []entity.ProductSpec) []entity.ProductSpec {
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		seen[spec.Key] = true
+	}
+	for _, hint := range hints {
+		if hint.Key == "" || seen[hint.Key] {
+			continue
+		}
+		seen[hint.Key] = true
+		specs = append(specs, hint)
+	}
+	return specs
+}
+
+func specKey(label string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if key, ok := specKeys[label]; ok {
+		return key
+	}
+	return strings.Trim(nonKeyChars.ReplaceAllString(label, "_"), "_")
+}
+
+// parseCondition maps "Baru" and "Bekas" to the condition enum, other values are kept lowercased
+func parseCondition(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "baru", "new":
+		return entity.ConditionNew
+	case "bekas", "used":
+		return entity.ConditionUsed
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// parseWeightGrams parses weights like "200 g", "1.500 gr", "1,5 kg" or
+// "1 Kilogram" into grams
+func parseWeightGrams(value string) (float64, bool) {
+	match := weightPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, false
+	}
+	number, err := parseIndonesianNumber(match[1])
+	if err != nil {
+		return 0, false
+	}
+	if unit := strings.ToLower(match[2]); unit == "kg" || unit == "kilogram" {
+		number *= 1000
+	}
+	return number, true
+}
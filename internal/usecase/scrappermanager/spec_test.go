@@ -0,0 +1,104 @@
+package scrappermanager
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestParseWeightGrams(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   float64
+		wantOK bool
+	}{
+		{"200 g", 200, true},
+		{"200gr", 200, true},
+		{"1.500 g", 1500, true},
+		{"1.000 gr", 1000, true},
+		{"1,5 kg", 1500, true},
+		{"2 Kilogram", 2000, true},
+		{"0,25 kg", 250, true},
+		{"Ringan", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseWeightGrams(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseWeightGrams(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestNormaliseSpecs(t *testing.T) {
+	specs := normaliseSpecs([]entity.ProductSpec{
+		{Label: "Kondisi", RawValue: "Baru"},
+		{Label: "Berat Satuan", RawValue: "1.200 g"},
+		{Label: "Min. Pemesanan", RawValue: "1.000 Buah"},
+		{Label: "Kapasitas Baterai", RawValue: "5000 mAh"},
+	})
+
+	want := []struct {
+		key, value string
+		number     float64
+		unit       string
+	}{
+		{"condition", entity.ConditionNew, 0, ""},
+		{"weight", "1.200 g", 1200, "g"},
+		{"min_order", "1.000 Buah", 1000, "pcs"},
+		{"kapasitas_baterai", "5000 mAh", 0, ""},
+	}
+	for i, w := range want {
+		spec := specs[i]
+		number := 0.0
+		if spec.Number != nil {
+			number = *spec.Number
+		}
+		if spec.Key != w.key || spec.Value != w.value || number != w.number || spec.Unit != w.unit {
+			t.Errorf("spec %d = %s %q %v %s, want %s %q %v %s", i, spec.Key, spec.Value, number, spec.Unit, w.key, w.value, w.number, w.unit)
+		}
+	}
+}
+
+func TestDescriptionSpecHints(t *testing.T) {
+	description := strings.Join([]string{
+		"Spesifikasi:",
+		"RAM: 8GB",
+		"- Baterai : 5000 mAh",
+		"• Layar = 6.4 inch",
+		"Buka jam 08:00 - 17:00",
+		"Jam operasional: 08:00-17:00",
+		"Info lengkap https://www.tokopedia.com/samsung-official",
+		"https://www.tokopedia.com/samsung-official",
+		"Barang dijamin original",
+	}, "\n")
+
+	var got []string
+	for _, hint := range descriptionSpecHints(description) {
+		if hint.Source != entity.SpecSourceDescription {
+			t.Errorf("hint %s has source %q", hint.Label, hint.Source)
+		}
+		got = append(got, hint.Label+"="+hint.RawValue)
+	}
+	want := []string{"RAM=8GB", "Baterai=5000 mAh", "Layar=6.4 inch"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("descriptionSpecHints = %q, want %q", got, want)
+	}
+}
+
+func TestMergeSpecHints(t *testing.T) {
+	specs := []entity.ProductSpec{{Key: "ram", RawValue: "8 GB"}}
+	hints := []entity.ProductSpec{
+		{Key: "ram", RawValue: "12GB"},
+		{Key: "baterai", RawValue: "5000 mAh"},
+		{Key: "baterai", RawValue: "4000 mAh"},
+		{Key: "", RawValue: "no key"},
+	}
+
+	got := mergeSpecHints(specs, hints)
+	want := []entity.ProductSpec{{Key: "ram", RawValue: "8 GB"}, {Key: "baterai", RawValue: "5000 mAh"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeSpecHints = %+v, want %+v", got, want)
+	}
+}
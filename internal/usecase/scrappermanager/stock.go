@@ -0,0 +1,59 @@
+package scrappermanager
+
+import (
+	"strings"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+// lowStockThreshold is the stock at or below which a product counts as almost sold out
+const lowStockThreshold = 5
+
+// parseStock parses stock labels like "Stok: 120", "Sisa 3" or "Stok habis"
+func parseStock(text string) (int64, error) {
+	if isSoldOutText(text) {
+		return 0, nil
+	}
+	return parseIndonesianCount(text)
+}
+
+func isSoldOutText(text string) bool {
+	text = strings.ToLower(text)
+	return strings.Contains(text, "habis") || strings.Contains(text, "kosong")
+}
+
+// schemaAvailability maps schema.org ItemAvailability values
+var schemaAvailability = map[string]entity.Availability{
+	"instock":             entity.AvailabilityInStock,
+	"instoreonly":         entity.AvailabilityInStock,
+	"onlineonly":          entity.AvailabilityInStock,
+	"limitedavailability": entity.AvailabilityLowStock,
+	"outofstock":          entity.AvailabilityOutOfStock,
+	"soldout":             entity.AvailabilityOutOfStock,
+	"discontinued":        entity.AvailabilityOutOfStock,
+}
+
+// resolveAvailability settles the availability of a product from what was
+// extracted. The stock shown on the page wins over the schema.org value when
+// it can be read, text without a count like "Stok tersedia" leaves it as is.
+func resolveAvailability(product *entity.Product) {
+	switch {
+	case product.StockText != "" && isSoldOutText(product.StockText):
+		product.Availability = entity.AvailabilityOutOfStock
+	case product.StockText != "" && stockParsesToZero(product.StockText):
+		product.Availability = entity.AvailabilityOutOfStock
+	case product.Stock > 0 && product.Stock <= lowStockThreshold:
+		product.Availability = entity.AvailabilityLowStock
+	case strings.Contains(strings.ToLower(product.StockText), "sisa"):
+		product.Availability = entity.AvailabilityLowStock
+	case product.Stock > 0 && product.Availability == entity.AvailabilityUnknown:
+		product.Availability = entity.AvailabilityInStock
+	}
+}
+
+// stockParsesToZero tells a stock text that reads 0, e.g. "Stok: 0", from one
+// without a count
+func stockParsesToZero(text string) bool {
+	stock, err := parseStock(text)
+	return err == nil && stock == 0
+}
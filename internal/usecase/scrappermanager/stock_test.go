@@ -0,0 +1,54 @@
+package scrappermanager
+
+import (
+	"testing"
+
+	"github.com/indragunawan95/topedcrawler/internal/entity"
+)
+
+func TestParseStock(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    int64
+		wantErr bool
+	}{
+		{"Stok: 120", 120, false},
+		{"Sisa 3", 3, false},
+		{"Stok habis", 0, false},
+		{"Stok kosong", 0, false},
+		{"Stok: 0", 0, false},
+		{"Stok tersedia", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseStock(tt.text)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseStock(%q) = %d, %v, want %d, wantErr %v", tt.text, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveAvailability(t *testing.T) {
+	tests := []struct {
+		name   string
+		before entity.Product
+		want   entity.Availability
+	}{
+		{"sold out text", entity.Product{StockText: "Stok habis", Availability: entity.AvailabilityInStock}, entity.AvailabilityOutOfStock},
+		{"zero stock", entity.Product{StockText: "Stok: 0", Availability: entity.AvailabilityInStock}, entity.AvailabilityOutOfStock},
+		{"text without a count keeps schema.org", entity.Product{StockText: "Stok tersedia", Availability: entity.AvailabilityInStock}, entity.AvailabilityInStock},
+		{"text without a count stays unknown", entity.Product{StockText: "Stok tersedia"}, entity.AvailabilityUnknown},
+		{"low stock", entity.Product{StockText: "Stok: 4", Stock: 4}, entity.AvailabilityLowStock},
+		{"sisa", entity.Product{StockText: "Sisa 12", Stock: 12}, entity.AvailabilityLowStock},
+		{"in stock", entity.Product{StockText: "Stok: 120", Stock: 120}, entity.AvailabilityInStock},
+		{"schema.org only", entity.Product{Availability: entity.AvailabilityOutOfStock}, entity.AvailabilityOutOfStock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.before
+			resolveAvailability(&product)
+			if product.Availability != tt.want {
+				t.Errorf("availability = %q, want %q", product.Availability, tt.want)
+			}
+		})
+	}
+}